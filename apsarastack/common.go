@@ -474,6 +474,16 @@ func userDataHashSum(user_data string) string {
 	return string(v)
 }
 
+// setExtraQueryParams sets the request parameters which the SDK has no fields for yet. The API reads them from
+// the query string like the ones set through the fields, so empty values are left out the same way.
+func setExtraQueryParams(queryParams map[string]string, params map[string]string) {
+	for key, value := range params {
+		if value != "" {
+			queryParams[key] = value
+		}
+	}
+}
+
 const ServerSideEncryptionAes256 = "AES256"
 const ServerSideEncryptionKMS = "KMS"
 
@@ -501,6 +511,8 @@ const (
 	TagResourceVSwitch       = TagResourceType("VSWITCH")
 	TagResourceRouteTable    = TagResourceType("ROUTETABLE")
 	TagResourceEip           = TagResourceType("EIP")
+	TagResourceNatGateway    = TagResourceType("NATGATEWAY")
+	TagResourceCbwp          = TagResourceType("COMMONBANDWIDTHPACKAGE")
 	TagResourcePlugin        = TagResourceType("plugin")
 	TagResourceApiGroup      = TagResourceType("apiGroup")
 	TagResourceApp           = TagResourceType("app")
@@ -10,6 +10,7 @@ import (
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/adb"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/alidns"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/bssopenapi"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cbn"
 	cdn_new "github.com/aliyun/alibaba-cloud-sdk-go/services/cdn"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/cms"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/cr"
@@ -81,6 +82,7 @@ type ApsaraStackClient struct {
 	rdsconn           *rds.Client
 	ramconn           *ram.Client
 	essconn           *ess.Client
+	cenconn           *cbn.Client
 	gpdbconn          *gpdb.Client
 	elasticsearchconn *elasticsearch.Client
 	hbaseconn         *hbase.Client
@@ -274,6 +276,41 @@ func (client *ApsaraStackClient) WithEssClient(do func(*ess.Client) (interface{}
 	return do(client.essconn)
 }
 
+func (client *ApsaraStackClient) WithCenClient(do func(*cbn.Client) (interface{}, error)) (interface{}, error) {
+	// Initialize the CEN client if necessary
+	if client.cenconn == nil {
+		endpoint := client.Config.CenEndpoint
+		if endpoint == "" {
+			endpoint = client.Config.CbnEndpoint
+		}
+		if endpoint == "" {
+			return nil, fmt.Errorf("unable to initialize the cen client: endpoint or domain is not provided for cen service")
+		}
+		if endpoint != "" {
+			endpoints.AddEndpointMapping(client.Config.RegionId, string(CENCode), endpoint)
+		}
+		if strings.HasPrefix(endpoint, "http") {
+			endpoint = strings.TrimPrefix(strings.TrimPrefix(endpoint, "http://"), "https://")
+		}
+		cenconn, err := cbn.NewClientWithOptions(client.Config.RegionId, client.getSdkConfig(), client.Config.getAuthCredential(true))
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize the CEN client: %#v", err)
+		}
+		cenconn.Domain = endpoint
+		cenconn.AppendUserAgent(Terraform, TerraformVersion)
+		cenconn.AppendUserAgent(Provider, ProviderVersion)
+		cenconn.AppendUserAgent(Module, client.Config.ConfigurationSource)
+		cenconn.SetHTTPSInsecure(client.Config.Insecure)
+		if client.Config.Proxy != "" {
+			cenconn.SetHttpsProxy(client.Config.Proxy)
+			cenconn.SetHttpProxy(client.Config.Proxy)
+		}
+		client.cenconn = cenconn
+	}
+
+	return do(client.cenconn)
+}
+
 func (client *ApsaraStackClient) WithRkvClient(do func(*r_kvstore.Client) (interface{}, error)) (interface{}, error) {
 	// Initialize the RKV client if necessary
 	if client.rkvconn == nil {
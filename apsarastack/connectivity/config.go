@@ -39,6 +39,7 @@ type Config struct {
 	SourceIp                 string
 	SecureTransport          string
 	ResourceSetName          string
+	DefaultDiskKMSKeyId      string
 	RamRoleArn               string
 	RamRoleSessionName       string
 	RamRolePolicy            string
@@ -0,0 +1,233 @@
+package apsarastack
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cbn"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceApsaraStackCenBandwidthPackages() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceApsaraStackCenBandwidthPackagesRead,
+
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+				ForceNew: true,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ValidateRegexp,
+				ForceNew:     true,
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// Computed values
+			"packages": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"bandwidth": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"charge_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"geographic_region_a_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"geographic_region_b_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"business_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"creation_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"expired_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceApsaraStackCenBandwidthPackagesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	request := cbn.CreateDescribeCenBandwidthPackagesRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "cbn", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+	if v, ok := d.GetOk("instance_id"); ok {
+		request.Filter = &[]cbn.DescribeCenBandwidthPackagesFilter{
+			{
+				Key:   "CenId",
+				Value: &[]string{v.(string)},
+			},
+		}
+	}
+
+	idsMap := make(map[string]string)
+	if v, ok := d.GetOk("ids"); ok {
+		for _, vv := range v.([]interface{}) {
+			idsMap[Trim(vv.(string))] = Trim(vv.(string))
+		}
+	}
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		r, err := regexp.Compile(v.(string))
+		if err != nil {
+			return WrapError(err)
+		}
+		nameRegex = r
+	}
+
+	var allPackages []cbn.CenBandwidthPackage
+	invoker := NewInvoker()
+	for {
+		var raw interface{}
+		if err := invoker.Run(func() error {
+			response, err := client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+				return cbnClient.DescribeCenBandwidthPackages(request)
+			})
+			raw = response
+			return err
+		}); err != nil {
+			return WrapErrorf(err, DataDefaultErrorMsg, "apsarastack_cen_bandwidth_packages", request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*cbn.DescribeCenBandwidthPackagesResponse)
+		if len(response.CenBandwidthPackages.CenBandwidthPackage) < 1 {
+			break
+		}
+
+		for _, pkg := range response.CenBandwidthPackages.CenBandwidthPackage {
+			if nameRegex != nil && !nameRegex.MatchString(pkg.Name) {
+				continue
+			}
+			if len(idsMap) > 0 {
+				if _, ok := idsMap[pkg.CenBandwidthPackageId]; !ok {
+					continue
+				}
+			}
+			allPackages = append(allPackages, pkg)
+		}
+
+		if len(response.CenBandwidthPackages.CenBandwidthPackage) < PageSizeLarge {
+			break
+		}
+		page, err := getNextpageNumber(request.PageNumber)
+		if err != nil {
+			return WrapError(err)
+		}
+		request.PageNumber = page
+	}
+
+	return cenBandwidthPackagesDescriptionAttributes(d, allPackages)
+}
+
+func cenBandwidthPackagesDescriptionAttributes(d *schema.ResourceData, packages []cbn.CenBandwidthPackage) error {
+	var ids []string
+	var names []string
+	var s []map[string]interface{}
+	for _, pkg := range packages {
+		instanceId := ""
+		if len(pkg.CenIds.CenId) > 0 {
+			instanceId = pkg.CenIds.CenId[0]
+		}
+		mapping := map[string]interface{}{
+			"id":                     pkg.CenBandwidthPackageId,
+			"instance_id":            instanceId,
+			"name":                   pkg.Name,
+			"description":            pkg.Description,
+			"bandwidth":              int(pkg.Bandwidth),
+			"charge_type":            pkg.BandwidthPackageChargeType,
+			"geographic_region_a_id": pkg.GeographicRegionAId,
+			"geographic_region_b_id": pkg.GeographicRegionBId,
+			"business_status":        pkg.BusinessStatus,
+			"status":                 pkg.Status,
+			"creation_time":          pkg.CreationTime,
+			"expired_time":           pkg.ExpiredTime,
+		}
+		ids = append(ids, pkg.CenBandwidthPackageId)
+		names = append(names, pkg.Name)
+		s = append(s, mapping)
+	}
+
+	d.SetId(dataResourceIdHash(ids))
+	if err := d.Set("packages", s); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("names", names); err != nil {
+		return WrapError(err)
+	}
+
+	// create a json file in current directory and write data source to it.
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		writeToFile(output.(string), s)
+	}
+	return nil
+}
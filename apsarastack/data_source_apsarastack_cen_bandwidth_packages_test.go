@@ -0,0 +1,114 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+)
+
+func TestAccApsaraStackCenBandwidthPackagesDataSourceBasic(t *testing.T) {
+	rand := acctest.RandIntRange(1000, 9999)
+
+	nameRegexConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackCenBandwidthPackagesDataSourceConfig(rand, map[string]string{
+			"name_regex": `"${apsarastack_cen_bandwidth_package.default.name}"`,
+		}),
+		fakeConfig: testAccCheckApsaraStackCenBandwidthPackagesDataSourceConfig(rand, map[string]string{
+			"name_regex": `"${apsarastack_cen_bandwidth_package.default.name}_fake"`,
+		}),
+	}
+
+	idsConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackCenBandwidthPackagesDataSourceConfig(rand, map[string]string{
+			"ids": `[ "${apsarastack_cen_bandwidth_package_attachment.default.bandwidth_package_id}" ]`,
+		}),
+		fakeConfig: testAccCheckApsaraStackCenBandwidthPackagesDataSourceConfig(rand, map[string]string{
+			"ids": `[ "${apsarastack_cen_bandwidth_package_attachment.default.bandwidth_package_id}_fake" ]`,
+		}),
+	}
+
+	instanceIdConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackCenBandwidthPackagesDataSourceConfig(rand, map[string]string{
+			"instance_id": `"${apsarastack_cen_bandwidth_package_attachment.default.instance_id}"`,
+		}),
+	}
+
+	allConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackCenBandwidthPackagesDataSourceConfig(rand, map[string]string{
+			"instance_id": `"${apsarastack_cen_bandwidth_package_attachment.default.instance_id}"`,
+			"ids":         `[ "${apsarastack_cen_bandwidth_package_attachment.default.bandwidth_package_id}" ]`,
+			"name_regex":  `"${apsarastack_cen_bandwidth_package.default.name}"`,
+		}),
+		fakeConfig: testAccCheckApsaraStackCenBandwidthPackagesDataSourceConfig(rand, map[string]string{
+			"instance_id": `"${apsarastack_cen_bandwidth_package_attachment.default.instance_id}"`,
+			"ids":         `[ "${apsarastack_cen_bandwidth_package_attachment.default.bandwidth_package_id}" ]`,
+			"name_regex":  `"${apsarastack_cen_bandwidth_package.default.name}_fake"`,
+		}),
+	}
+	cenBandwidthPackagesCheckInfo.dataSourceTestCheck(t, rand, nameRegexConf, idsConf, instanceIdConf, allConf)
+}
+
+func testAccCheckApsaraStackCenBandwidthPackagesDataSourceConfig(rand int, attrMap map[string]string) string {
+	var pairs []string
+	for k, v := range attrMap {
+		pairs = append(pairs, k+" = "+v)
+	}
+
+	config := fmt.Sprintf(`
+variable "name" {
+  default = "tf-testAccCenBandwidthPackagesDataSource%d"
+}
+
+resource "apsarastack_cen_instance" "default" {
+  name = "${var.name}"
+}
+
+resource "apsarastack_cen_bandwidth_package" "default" {
+  name = "${var.name}"
+  bandwidth = 5
+}
+
+resource "apsarastack_cen_bandwidth_package_attachment" "default" {
+  instance_id = "${apsarastack_cen_instance.default.id}"
+  bandwidth_package_id = "${apsarastack_cen_bandwidth_package.default.id}"
+}
+
+data "apsarastack_cen_bandwidth_packages" "default" {
+  %s
+}
+`, rand, strings.Join(pairs, "\n  "))
+	return config
+}
+
+var existsCenBandwidthPackagesMapFunc = func(rand int) map[string]string {
+	return map[string]string{
+		"ids.#":                             "1",
+		"names.#":                           "1",
+		"packages.#":                        "1",
+		"packages.0.id":                     CHECKSET,
+		"packages.0.instance_id":            CHECKSET,
+		"packages.0.name":                   fmt.Sprintf("tf-testAccCenBandwidthPackagesDataSource%d", rand),
+		"packages.0.bandwidth":              "5",
+		"packages.0.charge_type":            "PostPaid",
+		"packages.0.geographic_region_a_id": "China",
+		"packages.0.geographic_region_b_id": "China",
+		"packages.0.status":                 "InUse",
+		"packages.0.creation_time":          CHECKSET,
+	}
+}
+
+var fakeCenBandwidthPackagesMapFunc = func(rand int) map[string]string {
+	return map[string]string{
+		"ids.#":      "0",
+		"names.#":    "0",
+		"packages.#": "0",
+	}
+}
+
+var cenBandwidthPackagesCheckInfo = dataSourceAttr{
+	resourceId:   "data.apsarastack_cen_bandwidth_packages.default",
+	existMapFunc: existsCenBandwidthPackagesMapFunc,
+	fakeMapFunc:  fakeCenBandwidthPackagesMapFunc,
+}
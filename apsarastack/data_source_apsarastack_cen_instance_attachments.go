@@ -0,0 +1,169 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cbn"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceApsaraStackCenInstanceAttachments() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceApsaraStackCenInstanceAttachmentsRead,
+
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"child_instance_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{ChildInstanceTypeVpc, ChildInstanceTypeVbr, ChildInstanceTypeCcn}, false),
+			},
+			"child_instance_region_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// Computed values
+			"attachments": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"child_instance_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"child_instance_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"child_instance_region_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"child_instance_owner_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"child_instance_attach_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceApsaraStackCenInstanceAttachmentsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	request := cbn.CreateDescribeCenAttachedChildInstancesRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "cbn", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.CenId = d.Get("instance_id").(string)
+	if v, ok := d.GetOk("child_instance_type"); ok {
+		request.ChildInstanceType = v.(string)
+	}
+	if v, ok := d.GetOk("child_instance_region_id"); ok {
+		request.ChildInstanceRegionId = v.(string)
+	}
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+
+	var allChildInstances []cbn.ChildInstance
+	invoker := NewInvoker()
+	for {
+		var raw interface{}
+		if err := invoker.Run(func() error {
+			response, err := client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+				return cbnClient.DescribeCenAttachedChildInstances(request)
+			})
+			raw = response
+			return err
+		}); err != nil {
+			return WrapErrorf(err, DataDefaultErrorMsg, "apsarastack_cen_instance_attachments", request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*cbn.DescribeCenAttachedChildInstancesResponse)
+		allChildInstances = append(allChildInstances, response.ChildInstances.ChildInstance...)
+
+		if len(response.ChildInstances.ChildInstance) < PageSizeLarge {
+			break
+		}
+		page, err := getNextpageNumber(request.PageNumber)
+		if err != nil {
+			return WrapError(err)
+		}
+		request.PageNumber = page
+	}
+
+	var ids []string
+	var s []map[string]interface{}
+	for _, child := range allChildInstances {
+		id := fmt.Sprintf("%s%s%s", child.CenId, COLON_SEPARATED, child.ChildInstanceId)
+		mapping := map[string]interface{}{
+			"id":                         id,
+			"instance_id":                child.CenId,
+			"child_instance_id":          child.ChildInstanceId,
+			"child_instance_type":        child.ChildInstanceType,
+			"child_instance_region_id":   child.ChildInstanceRegionId,
+			"child_instance_owner_id":    int(child.ChildInstanceOwnerId),
+			"child_instance_attach_time": child.ChildInstanceAttachTime,
+			"status":                     child.Status,
+		}
+		ids = append(ids, id)
+		s = append(s, mapping)
+	}
+
+	d.SetId(dataResourceIdHash(ids))
+	if err := d.Set("attachments", s); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return WrapError(err)
+	}
+
+	// create a json file in current directory and write data source to it.
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		writeToFile(output.(string), s)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+)
+
+func TestAccApsaraStackCenInstanceAttachmentsDataSourceBasic(t *testing.T) {
+	rand := acctest.RandIntRange(1000, 9999)
+
+	instanceIdConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackCenInstanceAttachmentsDataSourceConfig(rand, map[string]string{
+			"instance_id": `"${apsarastack_cen_instance_attachment.default.instance_id}"`,
+		}),
+	}
+
+	childInstanceTypeConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackCenInstanceAttachmentsDataSourceConfig(rand, map[string]string{
+			"instance_id":         `"${apsarastack_cen_instance_attachment.default.instance_id}"`,
+			"child_instance_type": `"VPC"`,
+		}),
+		fakeConfig: testAccCheckApsaraStackCenInstanceAttachmentsDataSourceConfig(rand, map[string]string{
+			"instance_id":         `"${apsarastack_cen_instance_attachment.default.instance_id}"`,
+			"child_instance_type": `"VBR"`,
+		}),
+	}
+	cenInstanceAttachmentsCheckInfo.dataSourceTestCheck(t, rand, instanceIdConf, childInstanceTypeConf)
+}
+
+func testAccCheckApsaraStackCenInstanceAttachmentsDataSourceConfig(rand int, attrMap map[string]string) string {
+	var pairs []string
+	for k, v := range attrMap {
+		pairs = append(pairs, k+" = "+v)
+	}
+
+	config := fmt.Sprintf(`
+%s
+
+data "apsarastack_cen_instance_attachments" "default" {
+  %s
+}
+`, testAccCenInstanceAttachmentConfig(rand), strings.Join(pairs, "\n  "))
+	return config
+}
+
+var existsCenInstanceAttachmentsMapFunc = func(rand int) map[string]string {
+	return map[string]string{
+		"ids.#":                                  "1",
+		"attachments.#":                          "1",
+		"attachments.0.id":                       CHECKSET,
+		"attachments.0.instance_id":              CHECKSET,
+		"attachments.0.child_instance_id":        CHECKSET,
+		"attachments.0.child_instance_type":      "VPC",
+		"attachments.0.child_instance_region_id": CHECKSET,
+		"attachments.0.status":                   "Attached",
+	}
+}
+
+var fakeCenInstanceAttachmentsMapFunc = func(rand int) map[string]string {
+	return map[string]string{
+		"ids.#":         "0",
+		"attachments.#": "0",
+	}
+}
+
+var cenInstanceAttachmentsCheckInfo = dataSourceAttr{
+	resourceId:   "data.apsarastack_cen_instance_attachments.default",
+	existMapFunc: existsCenInstanceAttachmentsMapFunc,
+	fakeMapFunc:  fakeCenInstanceAttachmentsMapFunc,
+}
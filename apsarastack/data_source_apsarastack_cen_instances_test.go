@@ -0,0 +1,107 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+)
+
+func TestAccApsaraStackCenInstancesDataSourceBasic(t *testing.T) {
+	rand := acctest.RandIntRange(1000, 9999)
+
+	nameRegexConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackCenInstancesDataSourceConfig(rand, map[string]string{
+			"name_regex": `"${apsarastack_cen_instance.default.name}"`,
+		}),
+		fakeConfig: testAccCheckApsaraStackCenInstancesDataSourceConfig(rand, map[string]string{
+			"name_regex": `"${apsarastack_cen_instance.default.name}_fake"`,
+		}),
+	}
+
+	idsConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackCenInstancesDataSourceConfig(rand, map[string]string{
+			"ids": `[ "${apsarastack_cen_instance.default.id}" ]`,
+		}),
+		fakeConfig: testAccCheckApsaraStackCenInstancesDataSourceConfig(rand, map[string]string{
+			"ids": `[ "${apsarastack_cen_instance.default.id}_fake" ]`,
+		}),
+	}
+
+	statusConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackCenInstancesDataSourceConfig(rand, map[string]string{
+			"ids":    `[ "${apsarastack_cen_instance.default.id}" ]`,
+			"status": `"Active"`,
+		}),
+		fakeConfig: testAccCheckApsaraStackCenInstancesDataSourceConfig(rand, map[string]string{
+			"ids":    `[ "${apsarastack_cen_instance.default.id}" ]`,
+			"status": `"Deleting"`,
+		}),
+	}
+
+	allConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackCenInstancesDataSourceConfig(rand, map[string]string{
+			"ids":        `[ "${apsarastack_cen_instance.default.id}" ]`,
+			"name_regex": `"${apsarastack_cen_instance.default.name}"`,
+			"status":     `"Active"`,
+		}),
+		fakeConfig: testAccCheckApsaraStackCenInstancesDataSourceConfig(rand, map[string]string{
+			"ids":        `[ "${apsarastack_cen_instance.default.id}_fake" ]`,
+			"name_regex": `"${apsarastack_cen_instance.default.name}"`,
+			"status":     `"Active"`,
+		}),
+	}
+	cenInstancesCheckInfo.dataSourceTestCheck(t, rand, nameRegexConf, idsConf, statusConf, allConf)
+}
+
+func testAccCheckApsaraStackCenInstancesDataSourceConfig(rand int, attrMap map[string]string) string {
+	var pairs []string
+	for k, v := range attrMap {
+		pairs = append(pairs, k+" = "+v)
+	}
+
+	config := fmt.Sprintf(`
+variable "name" {
+  default = "tf-testAccCenInstancesDataSource%d"
+}
+
+resource "apsarastack_cen_instance" "default" {
+  name = "${var.name}"
+  description = "${var.name}_description"
+}
+
+data "apsarastack_cen_instances" "default" {
+  %s
+}
+`, rand, strings.Join(pairs, "\n  "))
+	return config
+}
+
+var existsCenInstancesMapFunc = func(rand int) map[string]string {
+	return map[string]string{
+		"ids.#":                               "1",
+		"names.#":                             "1",
+		"instances.#":                         "1",
+		"instances.0.id":                      CHECKSET,
+		"instances.0.name":                    fmt.Sprintf("tf-testAccCenInstancesDataSource%d", rand),
+		"instances.0.description":             fmt.Sprintf("tf-testAccCenInstancesDataSource%d_description", rand),
+		"instances.0.status":                  "Active",
+		"instances.0.protection_level":        CHECKSET,
+		"instances.0.bandwidth_package_ids.#": "0",
+	}
+}
+
+var fakeCenInstancesMapFunc = func(rand int) map[string]string {
+	return map[string]string{
+		"ids.#":       "0",
+		"names.#":     "0",
+		"instances.#": "0",
+	}
+}
+
+var cenInstancesCheckInfo = dataSourceAttr{
+	resourceId:   "data.apsarastack_cen_instances.default",
+	existMapFunc: existsCenInstancesMapFunc,
+	fakeMapFunc:  fakeCenInstancesMapFunc,
+}
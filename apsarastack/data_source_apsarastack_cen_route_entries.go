@@ -0,0 +1,203 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cbn"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceApsaraStackCenRouteEntries() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceApsaraStackCenRouteEntriesRead,
+
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"route_table_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"cidr_block": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsCIDR,
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// Computed values
+			"entries": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"route_table_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cidr_block": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"next_hop_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"next_hop_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"route_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"publish_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"operational_mode": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"conflicts": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"cidr_block": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"region_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"instance_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"instance_type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"status": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceApsaraStackCenRouteEntriesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	cenId := d.Get("instance_id").(string)
+	routeTableId := d.Get("route_table_id").(string)
+
+	routeTable, err := vpcService.DescribeRouteTable(routeTableId)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	request := cbn.CreateDescribePublishedRouteEntriesRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "cbn", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.CenId = cenId
+	request.ChildInstanceId = routeTable.VpcId
+	request.ChildInstanceType = ChildInstanceTypeVpc
+	request.ChildInstanceRegionId = client.RegionId
+	request.ChildInstanceRouteTableId = routeTableId
+	if v, ok := d.GetOk("cidr_block"); ok {
+		request.DestinationCidrBlock = v.(string)
+	}
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+
+	var allEntries []cbn.PublishedRouteEntry
+	invoker := NewInvoker()
+	for {
+		var raw interface{}
+		if err := invoker.Run(func() error {
+			response, err := client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+				return cbnClient.DescribePublishedRouteEntries(request)
+			})
+			raw = response
+			return err
+		}); err != nil {
+			return WrapErrorf(err, DataDefaultErrorMsg, "apsarastack_cen_route_entries", request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*cbn.DescribePublishedRouteEntriesResponse)
+		allEntries = append(allEntries, response.PublishedRouteEntries.PublishedRouteEntry...)
+
+		if len(response.PublishedRouteEntries.PublishedRouteEntry) < PageSizeLarge {
+			break
+		}
+		page, err := getNextpageNumber(request.PageNumber)
+		if err != nil {
+			return WrapError(err)
+		}
+		request.PageNumber = page
+	}
+
+	var ids []string
+	var s []map[string]interface{}
+	for _, entry := range allEntries {
+		conflicts := make([]map[string]interface{}, 0, len(entry.Conflicts.Conflict))
+		for _, conflict := range entry.Conflicts.Conflict {
+			conflicts = append(conflicts, map[string]interface{}{
+				"cidr_block":    conflict.DestinationCidrBlock,
+				"region_id":     conflict.RegionId,
+				"instance_id":   conflict.InstanceId,
+				"instance_type": conflict.InstanceType,
+				"status":        conflict.Status,
+			})
+		}
+		mapping := map[string]interface{}{
+			"route_table_id":   entry.ChildInstanceRouteTableId,
+			"cidr_block":       entry.DestinationCidrBlock,
+			"next_hop_type":    entry.NextHopType,
+			"next_hop_id":      entry.NextHopId,
+			"route_type":       entry.RouteType,
+			"publish_status":   entry.PublishStatus,
+			"operational_mode": entry.OperationalMode,
+			"conflicts":        conflicts,
+		}
+		ids = append(ids, fmt.Sprintf("%s%s%s%s%s", cenId, COLON_SEPARATED, entry.ChildInstanceRouteTableId, COLON_SEPARATED, entry.DestinationCidrBlock))
+		s = append(s, mapping)
+	}
+
+	d.SetId(dataResourceIdHash(ids))
+	if err := d.Set("entries", s); err != nil {
+		return WrapError(err)
+	}
+
+	// create a json file in current directory and write data source to it.
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		writeToFile(output.(string), s)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+)
+
+func TestAccApsaraStackCenRouteEntriesDataSourceBasic(t *testing.T) {
+	rand := acctest.RandIntRange(1000, 9999)
+
+	cidrBlockConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackCenRouteEntriesDataSourceConfig(rand, map[string]string{
+			"cidr_block": `"${apsarastack_cen_route_entry.default.cidr_block}"`,
+		}),
+		fakeConfig: testAccCheckApsaraStackCenRouteEntriesDataSourceConfig(rand, map[string]string{
+			"cidr_block": `"12.0.0.0/16"`,
+		}),
+	}
+	cenRouteEntriesCheckInfo.dataSourceTestCheck(t, rand, cidrBlockConf)
+}
+
+func testAccCheckApsaraStackCenRouteEntriesDataSourceConfig(rand int, attrMap map[string]string) string {
+	var pairs []string
+	for k, v := range attrMap {
+		pairs = append(pairs, k+" = "+v)
+	}
+
+	config := fmt.Sprintf(`
+%s
+
+data "apsarastack_cen_route_entries" "default" {
+  instance_id = "${apsarastack_cen_route_entry.default.instance_id}"
+  route_table_id = "${apsarastack_cen_route_entry.default.route_table_id}"
+  %s
+}
+`, testAccCenRouteEntryConfig(rand), strings.Join(pairs, "\n  "))
+	return config
+}
+
+var existsCenRouteEntriesMapFunc = func(rand int) map[string]string {
+	return map[string]string{
+		"entries.#":                "1",
+		"entries.0.route_table_id": CHECKSET,
+		"entries.0.cidr_block":     "11.0.0.0/16",
+		"entries.0.next_hop_type":  "Instance",
+		"entries.0.next_hop_id":    CHECKSET,
+		"entries.0.route_type":     "Custom",
+		"entries.0.publish_status": "Published",
+		"entries.0.conflicts.#":    "0",
+	}
+}
+
+var fakeCenRouteEntriesMapFunc = func(rand int) map[string]string {
+	return map[string]string{
+		"entries.#": "0",
+	}
+}
+
+var cenRouteEntriesCheckInfo = dataSourceAttr{
+	resourceId:   "data.apsarastack_cen_route_entries.default",
+	existMapFunc: existsCenRouteEntriesMapFunc,
+	fakeMapFunc:  fakeCenRouteEntriesMapFunc,
+}
@@ -22,6 +22,7 @@ func dataSourceApsaraStackCommonBandwidthPackages() *schema.Resource {
 				ValidateFunc: validation.ValidateRegexp,
 				ForceNew:     true,
 			},
+			"tags": tagsSchema(),
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -107,6 +108,7 @@ func dataSourceApsaraStackCommonBandwidthPackages() *schema.Resource {
 }
 func dataSourceApsaraStackCommonBandwidthPackagesRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
 
 	request := vpc.CreateDescribeCommonBandwidthPackagesRequest()
 	if strings.ToLower(client.Config.Protocol) == "https" {
@@ -166,6 +168,15 @@ func dataSourceApsaraStackCommonBandwidthPackagesRead(d *schema.ResourceData, me
 					continue
 				}
 			}
+			if value, ok := d.GetOk("tags"); ok && len(value.(map[string]interface{})) > 0 {
+				tags, err := vpcService.DescribeTags(cbwp.BandwidthPackageId, value.(map[string]interface{}), TagResourceCbwp)
+				if err != nil {
+					return WrapError(err)
+				}
+				if len(tags) < 1 {
+					continue
+				}
+			}
 			allCommonBandwidthPackages = append(allCommonBandwidthPackages, cbwp)
 		}
 
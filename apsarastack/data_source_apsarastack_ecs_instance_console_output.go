@@ -0,0 +1,92 @@
+package apsarastack
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceApsaraStackEcsInstanceConsoleOutput() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceApsaraStackEcsInstanceConsoleOutputRead,
+
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"remove_symbols": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"screenshot_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"wake_up": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"console_output": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_update_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceApsaraStackEcsInstanceConsoleOutputRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	instanceId := d.Get("instance_id").(string)
+
+	object, err := ecsService.DescribeInstanceConsoleOutput(instanceId, d.Get("remove_symbols").(bool))
+	if err != nil {
+		return WrapError(err)
+	}
+	output, err := base64.StdEncoding.DecodeString(object.ConsoleOutput)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	// The screenshot is a base64 encoded JPG image.
+	if path, ok := d.GetOk("screenshot_path"); ok && path.(string) != "" {
+		screenshot, err := ecsService.DescribeInstanceScreenshot(instanceId, d.Get("wake_up").(bool))
+		if err != nil {
+			return WrapError(err)
+		}
+		image, err := base64.StdEncoding.DecodeString(screenshot.Screenshot)
+		if err != nil {
+			return WrapError(err)
+		}
+		if err := ioutil.WriteFile(path.(string), image, 0644); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	d.SetId(instanceId)
+	d.Set("console_output", string(output))
+	d.Set("last_update_time", object.LastUpdateTime)
+
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		writeToFile(output.(string), map[string]interface{}{
+			"instance_id":      instanceId,
+			"console_output":   d.Get("console_output"),
+			"last_update_time": object.LastUpdateTime,
+		})
+	}
+	return nil
+}
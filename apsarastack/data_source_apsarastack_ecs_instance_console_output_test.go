@@ -0,0 +1,33 @@
+package apsarastack
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackEcsInstanceConsoleOutputDataSourceBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckApsaraStackInstancesDataSource + testAccCheckApsaraStackEcsInstanceConsoleOutputDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckApsaraStackDataSourceID("data.apsarastack_ecs_instance_console_output.default"),
+					resource.TestCheckResourceAttrPair("data.apsarastack_ecs_instance_console_output.default", "instance_id", "apsarastack_instance.default", "id"),
+					resource.TestCheckResourceAttrSet("data.apsarastack_ecs_instance_console_output.default", "console_output"),
+					resource.TestCheckResourceAttrSet("data.apsarastack_ecs_instance_console_output.default", "last_update_time"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckApsaraStackEcsInstanceConsoleOutputDataSource = `
+data "apsarastack_ecs_instance_console_output" "default" {
+  instance_id = "${apsarastack_instance.default.id}"
+}
+`
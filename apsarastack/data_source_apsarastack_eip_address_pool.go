@@ -0,0 +1,238 @@
+package apsarastack
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/responses"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+type eipAddressPool struct {
+	PublicIpAddressPoolId string `json:"PublicIpAddressPoolId"`
+	Name                  string `json:"Name"`
+	Description           string `json:"Description"`
+	Isp                   string `json:"Isp"`
+	Status                string `json:"Status"`
+	IpAddressRemaining    bool   `json:"IpAddressRemaining"`
+	TotalIpNum            int    `json:"TotalIpNum"`
+	UsedIpNum             int    `json:"UsedIpNum"`
+	ResourceGroupId       string `json:"ResourceGroupId"`
+	CreationTime          string `json:"CreationTime"`
+}
+
+type describeEipAddressPoolsResponse struct {
+	RequestId               string           `json:"RequestId"`
+	NextToken               string           `json:"NextToken"`
+	PublicIpAddressPoolList []eipAddressPool `json:"PublicIpAddressPoolList"`
+}
+
+func dataSourceApsaraStackEipAddressPool() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceApsaraStackEipAddressPoolRead,
+
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+			"isp": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Created", "Deleting", "Modifying"}, false),
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"pools": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"isp": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ip_address_remaining": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"total_ip_num": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"used_ip_num": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"resource_group_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"creation_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceApsaraStackEipAddressPoolRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	request := requests.NewCommonRequest()
+	if client.Config.Insecure {
+		request.SetHTTPSInsecure(client.Config.Insecure)
+	}
+	request.Method = "POST"
+	request.Product = "Vpc"
+	request.Version = "2016-04-28"
+	request.ApiName = "DescribePublicIpAddressPools"
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Domain = strings.TrimPrefix(strings.TrimPrefix(client.Config.VpcEndpoint, "http://"), "https://")
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup, "RegionId": client.RegionId, "Action": "DescribePublicIpAddressPools", "Version": "2016-04-28"}
+	request.QueryParams["MaxResults"] = fmt.Sprint(PageSizeLarge)
+	if v, ok := d.GetOk("isp"); ok {
+		request.QueryParams["Isp"] = v.(string)
+	}
+	if v, ok := d.GetOk("status"); ok {
+		request.QueryParams["Status"] = v.(string)
+	}
+
+	idsMap := make(map[string]string)
+	if v, ok := d.GetOk("ids"); ok {
+		for _, vv := range v.([]interface{}) {
+			idsMap[Trim(vv.(string))] = Trim(vv.(string))
+		}
+	}
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+
+	var pools []eipAddressPool
+	invoker := NewInvoker()
+	for {
+		var raw interface{}
+		if err := invoker.Run(func() error {
+			response, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+				return vpcClient.ProcessCommonRequest(request)
+			})
+			raw = response
+			return err
+		}); err != nil {
+			return WrapErrorf(err, DataDefaultErrorMsg, "apsarastack_eip_address_pool", request.ApiName, ApsaraStackSdkGoERROR)
+		}
+		bresponse, _ := raw.(*responses.CommonResponse)
+		addDebug(request.ApiName, raw, request)
+		var response describeEipAddressPoolsResponse
+		if err := json.Unmarshal(bresponse.GetHttpContentBytes(), &response); err != nil {
+			return WrapError(err)
+		}
+		for _, pool := range response.PublicIpAddressPoolList {
+			if len(idsMap) > 0 {
+				if _, ok := idsMap[pool.PublicIpAddressPoolId]; !ok {
+					continue
+				}
+			}
+			if nameRegex != nil && !nameRegex.MatchString(pool.Name) {
+				continue
+			}
+			pools = append(pools, pool)
+		}
+		if response.NextToken == "" {
+			break
+		}
+		request.QueryParams["NextToken"] = response.NextToken
+	}
+
+	var ids []string
+	var names []string
+	var s []map[string]interface{}
+	for _, pool := range pools {
+		mapping := map[string]interface{}{
+			"id":                   pool.PublicIpAddressPoolId,
+			"name":                 pool.Name,
+			"description":          pool.Description,
+			"isp":                  pool.Isp,
+			"status":               pool.Status,
+			"ip_address_remaining": pool.IpAddressRemaining,
+			"total_ip_num":         pool.TotalIpNum,
+			"used_ip_num":          pool.UsedIpNum,
+			"resource_group_id":    pool.ResourceGroupId,
+			"creation_time":        pool.CreationTime,
+		}
+		ids = append(ids, pool.PublicIpAddressPoolId)
+		names = append(names, pool.Name)
+		s = append(s, mapping)
+	}
+
+	d.SetId(dataResourceIdHash(ids))
+	if err := d.Set("ids", ids); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("names", names); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("pools", s); err != nil {
+		return WrapError(err)
+	}
+
+	// create a json file in current directory and write data source to it.
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		writeToFile(output.(string), s)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package apsarastack
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+// Address pools are set up by the platform operator, so the test only reads the ones that exist.
+func TestAccApsaraStackEipAddressPoolDataSourceBasic(t *testing.T) {
+	resourceId := "data.apsarastack_eip_address_pool.default"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "apsarastack_eip_address_pool" "default" {
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceId, "ids.#"),
+					resource.TestCheckResourceAttrSet(resourceId, "pools.#"),
+				),
+			},
+			{
+				Config: `
+data "apsarastack_eip_address_pool" "default" {
+  name_regex = "^tf-testacc-fake-pool$"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "ids.#", "0"),
+					resource.TestCheckResourceAttr(resourceId, "names.#", "0"),
+					resource.TestCheckResourceAttr(resourceId, "pools.#", "0"),
+				),
+			},
+		},
+	})
+}
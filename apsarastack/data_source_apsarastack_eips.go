@@ -30,6 +30,7 @@ func dataSourceApsaraStackEips() *schema.Resource {
 				MinItems: 1,
 			},
 
+			"tags": tagsSchema(),
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -77,6 +78,7 @@ func dataSourceApsaraStackEips() *schema.Resource {
 }
 func dataSourceApsaraStackEipsRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
 
 	request := vpc.CreateDescribeEipAddressesRequest()
 	if strings.ToLower(client.Config.Protocol) == "https" {
@@ -132,6 +134,15 @@ func dataSourceApsaraStackEipsRead(d *schema.ResourceData, meta interface{}) err
 					continue
 				}
 			}
+			if value, ok := d.GetOk("tags"); ok && len(value.(map[string]interface{})) > 0 {
+				tags, err := vpcService.DescribeTags(e.AllocationId, value.(map[string]interface{}), TagResourceEip)
+				if err != nil {
+					return WrapError(err)
+				}
+				if len(tags) < 1 {
+					continue
+				}
+			}
 
 			allEips = append(allEips, e)
 		}
@@ -0,0 +1,400 @@
+package apsarastack
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// The system disk categories which are checked for every recommended instance type.
+var instanceTypeRecommendationDiskCategories = []string{
+	string(DiskCloudEfficiency),
+	string(DiskCloudSSD),
+	string(DiskCloudESSD),
+	string(DiskCloud),
+}
+
+type instanceTypeRecommendation struct {
+	InstanceType ecs.InstanceType
+	Zones        []instanceTypeRecommendationZone
+	Preferred    bool
+}
+
+type instanceTypeRecommendationZone struct {
+	ZoneId               string
+	StatusCategory       string
+	SystemDiskCategories []string
+}
+
+func dataSourceApsaraStackInstanceTypeRecommendation() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceApsaraStackInstanceTypeRecommendationRead,
+
+		Schema: map[string]*schema.Schema{
+			"min_cpu_core_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"max_cpu_core_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"min_memory_size": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				ForceNew: true,
+			},
+			"max_memory_size": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				ForceNew: true,
+			},
+			"gpu_amount": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"gpu_spec": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"zone_ids": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"vswitch_id"},
+			},
+			"vswitch_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"zone_ids"},
+			},
+			"preferred_instance_type_family": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^ecs\..*`), "prefix must be 'ecs.'"),
+			},
+			"system_disk_category": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(instanceTypeRecommendationDiskCategories, false),
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"recommendations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_type_family": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cpu_core_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"memory_size": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"gpu_amount": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"gpu_spec": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"priority": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"zone_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"zones": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"zone_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"status_category": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"system_disk_categories": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceApsaraStackInstanceTypeRecommendationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	candidateZones := make(map[string]bool)
+	if v, ok := d.GetOk("zone_ids"); ok {
+		for _, zoneId := range v.([]interface{}) {
+			candidateZones[zoneId.(string)] = true
+		}
+	} else if v, ok := d.GetOk("vswitch_id"); ok {
+		vpcService := VpcService{client}
+		vsw, err := vpcService.DescribeVSwitch(v.(string))
+		if err != nil {
+			return WrapError(err)
+		}
+		candidateZones[vsw.ZoneId] = true
+	}
+
+	// The instance types which have stock, grouped by zone.
+	stock, err := describeInstanceTypeRecommendationStock(client, "")
+	if err != nil {
+		return WrapError(err)
+	}
+	for zoneId := range stock {
+		if len(candidateZones) > 0 && !candidateZones[zoneId] {
+			delete(stock, zoneId)
+		}
+	}
+
+	// The instance types which support each system disk category, grouped by zone.
+	diskSupport := make(map[string]map[string]map[string]string)
+	for _, category := range instanceTypeRecommendationDiskCategories {
+		supported, err := describeInstanceTypeRecommendationStock(client, category)
+		if err != nil {
+			return WrapError(err)
+		}
+		diskSupport[category] = supported
+	}
+
+	request := ecs.CreateDescribeInstanceTypesRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeInstanceTypes(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DataDefaultErrorMsg, "apsarastack_instance_type_recommendation", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	response, _ := raw.(*ecs.DescribeInstanceTypesResponse)
+
+	minCpu := d.Get("min_cpu_core_count").(int)
+	maxCpu := d.Get("max_cpu_core_count").(int)
+	minMem := d.Get("min_memory_size").(float64)
+	maxMem := d.Get("max_memory_size").(float64)
+	gpuAmount := d.Get("gpu_amount").(int)
+	gpuSpec := strings.TrimSpace(d.Get("gpu_spec").(string))
+	family := strings.TrimSpace(d.Get("preferred_instance_type_family").(string))
+	diskCategory := d.Get("system_disk_category").(string)
+
+	var recommendations []instanceTypeRecommendation
+	for _, t := range response.InstanceTypes.InstanceType {
+		if minCpu > 0 && t.CpuCoreCount < minCpu {
+			continue
+		}
+		if maxCpu > 0 && t.CpuCoreCount > maxCpu {
+			continue
+		}
+		if minMem > 0 && t.MemorySize < minMem {
+			continue
+		}
+		if maxMem > 0 && t.MemorySize > maxMem {
+			continue
+		}
+		if t.GPUAmount < gpuAmount {
+			continue
+		}
+		if gpuSpec != "" && !strings.EqualFold(t.GPUSpec, gpuSpec) {
+			continue
+		}
+
+		var zones []instanceTypeRecommendationZone
+		for zoneId, types := range stock {
+			statusCategory, ok := types[t.InstanceTypeId]
+			if !ok {
+				continue
+			}
+			zone := instanceTypeRecommendationZone{
+				ZoneId:         zoneId,
+				StatusCategory: statusCategory,
+			}
+			diskSupported := diskCategory == ""
+			for _, category := range instanceTypeRecommendationDiskCategories {
+				if _, ok := diskSupport[category][zoneId][t.InstanceTypeId]; ok {
+					zone.SystemDiskCategories = append(zone.SystemDiskCategories, category)
+					if category == diskCategory {
+						diskSupported = true
+					}
+				}
+			}
+			if !diskSupported {
+				continue
+			}
+			zones = append(zones, zone)
+		}
+		if len(zones) < 1 {
+			continue
+		}
+		sort.Slice(zones, func(i, j int) bool {
+			return zones[i].ZoneId < zones[j].ZoneId
+		})
+		recommendations = append(recommendations, instanceTypeRecommendation{
+			InstanceType: t,
+			Zones:        zones,
+			Preferred:    family != "" && t.InstanceTypeFamily == family,
+		})
+	}
+
+	// Rank the preferred family first, then the types which have stock in more zones,
+	// and at last the smallest types which meet the requirements.
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if a.Preferred != b.Preferred {
+			return a.Preferred
+		}
+		if len(a.Zones) != len(b.Zones) {
+			return len(a.Zones) > len(b.Zones)
+		}
+		if a.InstanceType.CpuCoreCount != b.InstanceType.CpuCoreCount {
+			return a.InstanceType.CpuCoreCount < b.InstanceType.CpuCoreCount
+		}
+		if a.InstanceType.MemorySize != b.InstanceType.MemorySize {
+			return a.InstanceType.MemorySize < b.InstanceType.MemorySize
+		}
+		return a.InstanceType.InstanceTypeId < b.InstanceType.InstanceTypeId
+	})
+
+	var ids []string
+	var s []map[string]interface{}
+	for i, r := range recommendations {
+		var zoneIds []string
+		var zones []map[string]interface{}
+		for _, zone := range r.Zones {
+			zoneIds = append(zoneIds, zone.ZoneId)
+			zones = append(zones, map[string]interface{}{
+				"zone_id":                zone.ZoneId,
+				"status_category":        zone.StatusCategory,
+				"system_disk_categories": zone.SystemDiskCategories,
+			})
+		}
+		mapping := map[string]interface{}{
+			"instance_type":        r.InstanceType.InstanceTypeId,
+			"instance_type_family": r.InstanceType.InstanceTypeFamily,
+			"cpu_core_count":       r.InstanceType.CpuCoreCount,
+			"memory_size":          r.InstanceType.MemorySize,
+			"gpu_amount":           r.InstanceType.GPUAmount,
+			"gpu_spec":             r.InstanceType.GPUSpec,
+			"priority":             i + 1,
+			"zone_ids":             zoneIds,
+			"zones":                zones,
+		}
+		ids = append(ids, r.InstanceType.InstanceTypeId)
+		s = append(s, mapping)
+	}
+
+	d.SetId(dataResourceIdHash(ids))
+	if err := d.Set("recommendations", s); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return WrapError(err)
+	}
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		writeToFile(output.(string), s)
+	}
+	return nil
+}
+
+// describeInstanceTypeRecommendationStock returns the instance types which have stock in every available zone,
+// mapping the zone id to the instance type and its status category. When systemDiskCategory is set, only the
+// instance types supporting it are returned.
+func describeInstanceTypeRecommendationStock(client *connectivity.ApsaraStackClient, systemDiskCategory string) (map[string]map[string]string, error) {
+	request := ecs.CreateDescribeAvailableResourceRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.DestinationResource = string(InstanceTypeResource)
+	request.IoOptimized = string(IOOptimized)
+	request.SystemDiskCategory = systemDiskCategory
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeAvailableResource(request)
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DataDefaultErrorMsg, "apsarastack_instance_type_recommendation", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	response, _ := raw.(*ecs.DescribeAvailableResourceResponse)
+
+	stock := make(map[string]map[string]string)
+	for _, zone := range response.AvailableZones.AvailableZone {
+		if zone.Status == string(SoldOut) {
+			continue
+		}
+		types := make(map[string]string)
+		for _, r := range zone.AvailableResources.AvailableResource {
+			if r.Type != string(InstanceTypeResource) {
+				continue
+			}
+			for _, t := range r.SupportedResources.SupportedResource {
+				if t.Status == string(SoldOut) || t.StatusCategory == "WithoutStock" {
+					continue
+				}
+				types[t.Value] = t.StatusCategory
+			}
+		}
+		stock[zone.ZoneId] = types
+	}
+	return stock, nil
+}
@@ -0,0 +1,75 @@
+package apsarastack
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackInstanceTypeRecommendationDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckApsaraStackInstanceTypeRecommendationDataSourceBasic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckApsaraStackDataSourceID("data.apsarastack_instance_type_recommendation.default"),
+					resource.TestCheckResourceAttrSet("data.apsarastack_instance_type_recommendation.default", "ids.#"),
+					resource.TestCheckResourceAttrSet("data.apsarastack_instance_type_recommendation.default", "recommendations.0.instance_type"),
+					resource.TestCheckResourceAttrSet("data.apsarastack_instance_type_recommendation.default", "recommendations.0.instance_type_family"),
+					resource.TestCheckResourceAttrSet("data.apsarastack_instance_type_recommendation.default", "recommendations.0.cpu_core_count"),
+					resource.TestCheckResourceAttrSet("data.apsarastack_instance_type_recommendation.default", "recommendations.0.memory_size"),
+					resource.TestCheckResourceAttr("data.apsarastack_instance_type_recommendation.default", "recommendations.0.priority", "1"),
+					resource.TestCheckResourceAttr("data.apsarastack_instance_type_recommendation.default", "recommendations.0.zone_ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.apsarastack_instance_type_recommendation.default", "recommendations.0.zones.0.zone_id", "data.apsarastack_zones.default", "zones.0.id"),
+					resource.TestCheckResourceAttrSet("data.apsarastack_instance_type_recommendation.default", "recommendations.0.zones.0.status_category"),
+					resource.TestCheckResourceAttr("data.apsarastack_instance_type_recommendation.default", "recommendations.0.zones.0.system_disk_categories.0", "cloud_efficiency"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccApsaraStackInstanceTypeRecommendationDataSource_empty(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckApsaraStackInstanceTypeRecommendationDataSourceEmpty,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckApsaraStackDataSourceID("data.apsarastack_instance_type_recommendation.empty"),
+					resource.TestCheckResourceAttr("data.apsarastack_instance_type_recommendation.empty", "recommendations.#", "0"),
+					resource.TestCheckResourceAttr("data.apsarastack_instance_type_recommendation.empty", "ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckApsaraStackInstanceTypeRecommendationDataSourceBasic = `
+data "apsarastack_zones" "default" {
+  available_resource_creation = "Instance"
+}
+
+data "apsarastack_instance_type_recommendation" "default" {
+  min_cpu_core_count   = 1
+  max_cpu_core_count   = 4
+  min_memory_size      = 1
+  max_memory_size      = 8
+  zone_ids             = [data.apsarastack_zones.default.zones.0.id]
+  system_disk_category = "cloud_efficiency"
+}
+`
+
+const testAccCheckApsaraStackInstanceTypeRecommendationDataSourceEmpty = `
+data "apsarastack_instance_type_recommendation" "empty" {
+  min_cpu_core_count = 1024
+  min_memory_size    = 1
+}
+`
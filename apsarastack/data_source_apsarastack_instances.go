@@ -68,6 +68,56 @@ func dataSourceApsaraStackInstances() *schema.Resource {
 				ForceNew: true,
 			},
 			"tags": tagsSchema(),
+			"security_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"instance_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"instance_type_family": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"key_pair_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"resource_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"private_ip_addresses": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 100,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"public_ip_addresses": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 100,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"page_number": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"page_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      PageSizeLarge,
+				ValidateFunc: validation.IntBetween(1, PageSizeXLarge),
+			},
 
 			"output_file": {
 				Type:     schema.TypeString,
@@ -76,6 +126,10 @@ func dataSourceApsaraStackInstances() *schema.Resource {
 			},
 
 			// Computed values
+			"total_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 			"names": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -159,11 +213,63 @@ func dataSourceApsaraStackInstances() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"public_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"eip_allocation_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_type_family": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_group_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"deployment_set_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"network_interfaces": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"network_interface_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"mac_address": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"primary_ip_address": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
 						"disk_device_mappings": {
 							Type:     schema.TypeList,
 							Computed: true,
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
+									"disk_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
 									"device": {
 										Type:     schema.TypeString,
 										Computed: true,
@@ -217,6 +323,30 @@ func dataSourceApsaraStackInstancesRead(d *schema.ResourceData, meta interface{}
 	if v, ok := d.GetOk("availability_zone"); ok && v.(string) != "" {
 		request.ZoneId = v.(string)
 	}
+	if v, ok := d.GetOk("image_id"); ok && v.(string) != "" {
+		request.ImageId = v.(string)
+	}
+	if v, ok := d.GetOk("security_group_id"); ok && v.(string) != "" {
+		request.SecurityGroupId = v.(string)
+	}
+	if v, ok := d.GetOk("instance_type"); ok && v.(string) != "" {
+		request.InstanceType = v.(string)
+	}
+	if v, ok := d.GetOk("instance_type_family"); ok && v.(string) != "" {
+		request.InstanceTypeFamily = v.(string)
+	}
+	if v, ok := d.GetOk("key_pair_name"); ok && v.(string) != "" {
+		request.KeyPairName = v.(string)
+	}
+	if v, ok := d.GetOk("resource_group_id"); ok && v.(string) != "" {
+		request.ResourceGroupId = v.(string)
+	}
+	if v, ok := d.GetOk("private_ip_addresses"); ok && len(v.([]interface{})) > 0 {
+		request.PrivateIpAddresses = convertListToJsonString(v.([]interface{}))
+	}
+	if v, ok := d.GetOk("public_ip_addresses"); ok && len(v.([]interface{})) > 0 {
+		request.PublicIpAddresses = convertListToJsonString(v.([]interface{}))
+	}
 	if v, ok := d.GetOk("tags"); ok {
 		var tags []ecs.DescribeInstancesTag
 
@@ -230,8 +360,15 @@ func dataSourceApsaraStackInstancesRead(d *schema.ResourceData, meta interface{}
 	}
 
 	var allInstances []ecs.Instance
-	request.PageSize = requests.NewInteger(PageSizeLarge)
+	pageSize := d.Get("page_size").(int)
+	request.PageSize = requests.NewInteger(pageSize)
 	request.PageNumber = requests.NewInteger(1)
+	// When page_number is set only the requested page is fetched, otherwise all of the pages are.
+	pageNumber, onePage := d.GetOk("page_number")
+	if onePage {
+		request.PageNumber = requests.NewInteger(pageNumber.(int))
+	}
+	totalCount := 0
 
 	for {
 		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
@@ -242,13 +379,14 @@ func dataSourceApsaraStackInstancesRead(d *schema.ResourceData, meta interface{}
 		}
 		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
 		response, _ := raw.(*ecs.DescribeInstancesResponse)
+		totalCount = response.TotalCount
 		if len(response.Instances.Instance) < 1 {
 			break
 		}
 
 		allInstances = append(allInstances, response.Instances.Instance...)
 
-		if len(response.Instances.Instance) < PageSizeLarge {
+		if onePage || len(response.Instances.Instance) < pageSize {
 			break
 		}
 
@@ -334,6 +472,7 @@ func dataSourceApsaraStackInstancesRead(d *schema.ResourceData, meta interface{}
 	if err != nil {
 		return WrapError(err)
 	}
+	d.Set("total_count", totalCount)
 
 	return instancessDescriptionAttributes(d, filteredInstancesTemp, instanceRoleNameMap, instanceDiskMappings, meta)
 }
@@ -370,12 +509,29 @@ func instancessDescriptionAttributes(d *schema.ResourceData, instances []ecs.Ins
 			"internet_max_bandwidth_out": inst.InternetMaxBandwidthOut,
 			"disk_device_mappings":       instanceDisksMap[inst.InstanceId],
 			"tags":                       ecsService.tagsToMap(inst.Tags.Tag),
+			"eip_allocation_id":          inst.EipAddress.AllocationId,
+			"instance_type_family":       inst.InstanceTypeFamily,
+			"resource_group_id":          inst.ResourceGroupId,
+			"deployment_set_id":          inst.DeploymentSetId,
 		}
 		if len(inst.InnerIpAddress.IpAddress) > 0 {
 			mapping["private_ip"] = inst.InnerIpAddress.IpAddress[0]
-		} else {
+		} else if len(inst.VpcAttributes.PrivateIpAddress.IpAddress) > 0 {
 			mapping["private_ip"] = inst.VpcAttributes.PrivateIpAddress.IpAddress[0]
 		}
+		if len(inst.PublicIpAddress.IpAddress) > 0 {
+			mapping["public_ip"] = inst.PublicIpAddress.IpAddress[0]
+		}
+		var networkInterfaces []map[string]interface{}
+		for _, eni := range inst.NetworkInterfaces.NetworkInterface {
+			networkInterfaces = append(networkInterfaces, map[string]interface{}{
+				"network_interface_id": eni.NetworkInterfaceId,
+				"mac_address":          eni.MacAddress,
+				"primary_ip_address":   eni.PrimaryIpAddress,
+				"type":                 eni.Type,
+			})
+		}
+		mapping["network_interfaces"] = networkInterfaces
 
 		ids = append(ids, inst.InstanceId)
 		names = append(names, inst.InstanceName)
@@ -442,6 +598,8 @@ func getInstanceDisksMappings(instanceMap map[string]string, meta interface{}) (
 			continue
 		}
 		mapping := map[string]interface{}{
+			"disk_id":  disk.DiskId,
+			"name":     disk.DiskName,
 			"device":   disk.Device,
 			"size":     disk.Size,
 			"category": disk.Category,
@@ -25,6 +25,51 @@ func TestAccApsaraStackInstancesDataSourceBasic(t *testing.T) {
 	})
 }
 
+func TestAccApsaraStackInstancesDataSourceFilters(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckApsaraStackInstancesDataSource + testAccCheckApsaraStackInstancesDataSourceFilters,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckApsaraStackDataSourceID("data.apsarastack_instances.filters"),
+					resource.TestCheckResourceAttr("data.apsarastack_instances.filters", "instances.#", "1"),
+					resource.TestCheckResourceAttr("data.apsarastack_instances.filters", "total_count", "1"),
+					resource.TestCheckResourceAttr("data.apsarastack_instances.filters", "instances.0.instance_type", "ecs.n4.xlarge"),
+					resource.TestCheckResourceAttrSet("data.apsarastack_instances.filters", "instances.0.instance_type_family"),
+					resource.TestCheckResourceAttrSet("data.apsarastack_instances.filters", "instances.0.creation_time"),
+					resource.TestCheckResourceAttr("data.apsarastack_instances.filters", "instances.0.network_interfaces.#", "1"),
+					resource.TestCheckResourceAttrSet("data.apsarastack_instances.filters", "instances.0.network_interfaces.0.network_interface_id"),
+					resource.TestCheckResourceAttr("data.apsarastack_instances.filters", "instances.0.disk_device_mappings.#", "1"),
+					resource.TestCheckResourceAttrSet("data.apsarastack_instances.filters", "instances.0.disk_device_mappings.0.disk_id"),
+					resource.TestCheckResourceAttr("data.apsarastack_instances.empty", "instances.#", "0"),
+					resource.TestCheckResourceAttr("data.apsarastack_instances.paged", "instances.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckApsaraStackInstancesDataSourceFilters = `
+data "apsarastack_instances" "filters" {
+  security_group_id    = "${apsarastack_security_group.default.id}"
+  instance_type        = "ecs.n4.xlarge"
+  private_ip_addresses = ["${apsarastack_instance.default.private_ip}"]
+}
+data "apsarastack_instances" "empty" {
+  security_group_id = "${apsarastack_security_group.default.id}"
+  instance_type     = "ecs.n4.large"
+}
+data "apsarastack_instances" "paged" {
+  security_group_id = "${apsarastack_security_group.default.id}"
+  page_number       = 1
+  page_size         = 1
+}
+`
+
 const testAccCheckApsaraStackInstancesDataSource = `
 variable "name" {
   default = "Tf-EcsInstanceDataSource"
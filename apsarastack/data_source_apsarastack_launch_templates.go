@@ -0,0 +1,196 @@
+package apsarastack
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceApsaraStackLaunchTemplates() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceApsaraStackLaunchTemplatesRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"template_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"template_resource_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			//Computed value
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"templates": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"default_version_number": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"latest_version_number": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"resource_group_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_by": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"create_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"modified_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceApsaraStackLaunchTemplatesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	request := ecs.CreateDescribeLaunchTemplatesRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	if v, ok := d.GetOk("template_name"); ok {
+		request.LaunchTemplateName = &[]string{v.(string)}
+	}
+	if v, ok := d.GetOk("template_resource_group_id"); ok {
+		request.TemplateResourceGroupId = v.(string)
+	}
+	request.PageNumber = requests.NewInteger(1)
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+
+	var regex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		regex = regexp.MustCompile(v.(string))
+	}
+	idsMap := make(map[string]string)
+	if v, ok := d.GetOk("ids"); ok {
+		for _, vv := range v.([]interface{}) {
+			idsMap[vv.(string)] = vv.(string)
+		}
+	}
+
+	var templates []ecs.LaunchTemplateSet
+	for {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DescribeLaunchTemplates(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DataDefaultErrorMsg, "apsarastack_launch_templates", request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*ecs.DescribeLaunchTemplatesResponse)
+		for _, template := range response.LaunchTemplateSets.LaunchTemplateSet {
+			if regex != nil && !regex.MatchString(template.LaunchTemplateName) {
+				continue
+			}
+			if len(idsMap) > 0 {
+				if _, ok := idsMap[template.LaunchTemplateId]; !ok {
+					continue
+				}
+			}
+			templates = append(templates, template)
+		}
+		if len(response.LaunchTemplateSets.LaunchTemplateSet) < PageSizeLarge {
+			break
+		}
+		page, err := getNextpageNumber(request.PageNumber)
+		if err != nil {
+			return WrapError(err)
+		}
+		request.PageNumber = page
+	}
+
+	return launchTemplatesDescriptionAttributes(d, templates)
+}
+
+func launchTemplatesDescriptionAttributes(d *schema.ResourceData, templates []ecs.LaunchTemplateSet) error {
+	var ids []string
+	var names []string
+	var s []map[string]interface{}
+	for _, template := range templates {
+		mapping := map[string]interface{}{
+			"id":                     template.LaunchTemplateId,
+			"name":                   template.LaunchTemplateName,
+			"default_version_number": int(template.DefaultVersionNumber),
+			"latest_version_number":  int(template.LatestVersionNumber),
+			"resource_group_id":      template.ResourceGroupId,
+			"created_by":             template.CreatedBy,
+			"create_time":            template.CreateTime,
+			"modified_time":          template.ModifiedTime,
+		}
+		ids = append(ids, template.LaunchTemplateId)
+		names = append(names, template.LaunchTemplateName)
+		s = append(s, mapping)
+	}
+
+	d.SetId(dataResourceIdHash(ids))
+	if err := d.Set("templates", s); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("names", names); err != nil {
+		return WrapError(err)
+	}
+	// create a json file in current directory and write data source to it.
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		writeToFile(output.(string), s)
+	}
+	return nil
+}
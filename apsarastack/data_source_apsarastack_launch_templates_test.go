@@ -0,0 +1,84 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+)
+
+func TestAccApsaraStackLaunchTemplatesDataSourceBasic(t *testing.T) {
+	rand := acctest.RandInt()
+	nameRegexConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackLaunchTemplatesDataSourceConfig(rand, map[string]string{
+			"name_regex": `"${apsarastack_launch_template.default.name}"`,
+		}),
+		fakeConfig: testAccCheckApsaraStackLaunchTemplatesDataSourceConfig(rand, map[string]string{
+			"name_regex": `"${apsarastack_launch_template.default.name}_fake"`,
+		}),
+	}
+	idsConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackLaunchTemplatesDataSourceConfig(rand, map[string]string{
+			"ids": `["${apsarastack_launch_template.default.id}"]`,
+		}),
+		fakeConfig: testAccCheckApsaraStackLaunchTemplatesDataSourceConfig(rand, map[string]string{
+			"ids": `["${apsarastack_launch_template.default.id}_fake"]`,
+		}),
+	}
+	allConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackLaunchTemplatesDataSourceConfig(rand, map[string]string{
+			"name_regex": `"${apsarastack_launch_template.default.name}"`,
+			"ids":        `["${apsarastack_launch_template.default.id}"]`,
+		}),
+		fakeConfig: testAccCheckApsaraStackLaunchTemplatesDataSourceConfig(rand, map[string]string{
+			"name_regex": `"${apsarastack_launch_template.default.name}"`,
+			"ids":        `["${apsarastack_launch_template.default.id}_fake"]`,
+		}),
+	}
+	launchTemplatesCheckInfo.dataSourceTestCheck(t, rand, nameRegexConf, idsConf, allConf)
+}
+
+func testAccCheckApsaraStackLaunchTemplatesDataSourceConfig(rand int, attrMap map[string]string) string {
+	var pairs []string
+	for k, v := range attrMap {
+		pairs = append(pairs, k+" = "+v)
+	}
+
+	config := fmt.Sprintf(`
+resource "apsarastack_launch_template" "default" {
+  name        = "tf-testAccLaunchTemplates%d"
+  description = "tf-testAccLaunchTemplates"
+}
+data "apsarastack_launch_templates" "default" {
+  %s
+}`, rand, strings.Join(pairs, "\n  "))
+	return config
+}
+
+var existLaunchTemplatesMapFunc = func(rand int) map[string]string {
+	return map[string]string{
+		"names.#":                            "1",
+		"ids.#":                              "1",
+		"templates.#":                        "1",
+		"templates.0.id":                     CHECKSET,
+		"templates.0.name":                   fmt.Sprintf("tf-testAccLaunchTemplates%d", rand),
+		"templates.0.default_version_number": "1",
+		"templates.0.latest_version_number":  "1",
+		"templates.0.create_time":            CHECKSET,
+	}
+}
+
+var fakeLaunchTemplatesMapFunc = func(rand int) map[string]string {
+	return map[string]string{
+		"names.#":     "0",
+		"ids.#":       "0",
+		"templates.#": "0",
+	}
+}
+
+var launchTemplatesCheckInfo = dataSourceAttr{
+	resourceId:   "data.apsarastack_launch_templates.default",
+	existMapFunc: existLaunchTemplatesMapFunc,
+	fakeMapFunc:  fakeLaunchTemplatesMapFunc,
+}
@@ -22,6 +22,7 @@ func dataSourceApsaraStackNatGateways() *schema.Resource {
 				ValidateFunc: validation.ValidateRegexp,
 				ForceNew:     true,
 			},
+			"tags": tagsSchema(),
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -88,6 +89,7 @@ func dataSourceApsaraStackNatGateways() *schema.Resource {
 }
 func dataSourceApsaraStackNatGatewaysRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
 
 	request := vpc.CreateDescribeNatGatewaysRequest()
 	if strings.ToLower(client.Config.Protocol) == "https" {
@@ -147,6 +149,15 @@ func dataSourceApsaraStackNatGatewaysRead(d *schema.ResourceData, meta interface
 					continue
 				}
 			}
+			if value, ok := d.GetOk("tags"); ok && len(value.(map[string]interface{})) > 0 {
+				tags, err := vpcService.DescribeTags(gateways.NatGatewayId, value.(map[string]interface{}), TagResourceNatGateway)
+				if err != nil {
+					return WrapError(err)
+				}
+				if len(tags) < 1 {
+					continue
+				}
+			}
 			allNatGateways = append(allNatGateways, gateways)
 		}
 
@@ -0,0 +1,358 @@
+package apsarastack
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceApsaraStackVpcCidrAllocator() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceApsaraStackVpcCidrAllocatorRead,
+
+		Schema: map[string]*schema.Schema{
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"prefix_length": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(16, 29),
+			},
+			"zone_ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"subnet_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"exclude_cidr_blocks": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDR,
+				},
+			},
+			"vswitch_name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// Computed values
+			"cidr_blocks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"subnets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"zone_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cidr_block": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceApsaraStackVpcCidrAllocatorRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	vpcId := d.Get("vpc_id").(string)
+
+	object, err := vpcService.DescribeVpc(vpcId)
+	if err != nil {
+		return WrapError(err)
+	}
+	vpcCidrs := append([]string{object.CidrBlock}, object.SecondaryCidrBlocks.SecondaryCidrBlock...)
+
+	zoneIds := []string{""}
+	zoned := false
+	if v, ok := d.GetOk("zone_ids"); ok && len(v.([]interface{})) > 0 {
+		zoneIds = zoneIds[:0]
+		zoned = true
+		for _, zoneId := range v.([]interface{}) {
+			zoneIds = append(zoneIds, zoneId.(string))
+		}
+	}
+	count := d.Get("subnet_count").(int)
+	prefixLength := d.Get("prefix_length").(int)
+
+	var used []string
+	if v, ok := d.GetOk("exclude_cidr_blocks"); ok {
+		for _, cidr := range v.([]interface{}) {
+			used = append(used, cidr.(string))
+		}
+	}
+
+	// The vSwitches matched by vswitch_name_regex belong to this allocation. Their cidr blocks are handed out
+	// again, so the result does not change once vSwitches are created from it.
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("vswitch_name_regex"); ok && v.(string) != "" {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+	allocated := make(map[string][]string)
+
+	request := vpc.CreateDescribeVSwitchesRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.VpcId = vpcId
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+
+	invoker := NewInvoker()
+	for {
+		var raw interface{}
+		if err := invoker.Run(func() error {
+			response, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+				return vpcClient.DescribeVSwitches(request)
+			})
+			raw = response
+			return err
+		}); err != nil {
+			return WrapErrorf(err, DataDefaultErrorMsg, "apsarastack_vpc_cidr_allocator", request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*vpc.DescribeVSwitchesResponse)
+		for _, vsw := range response.VSwitches.VSwitch {
+			used = append(used, vsw.CidrBlock)
+			if nameRegex == nil || !nameRegex.MatchString(vsw.VSwitchName) || !strings.HasSuffix(vsw.CidrBlock, fmt.Sprintf("/%d", prefixLength)) {
+				continue
+			}
+			zoneId := ""
+			if zoned {
+				zoneId = vsw.ZoneId
+			}
+			allocated[zoneId] = append(allocated[zoneId], vsw.CidrBlock)
+		}
+		if len(response.VSwitches.VSwitch) < PageSizeLarge {
+			break
+		}
+		page, err := getNextpageNumber(request.PageNumber)
+		if err != nil {
+			return WrapError(err)
+		}
+		request.PageNumber = page
+	}
+
+	for zoneId := range allocated {
+		if err := sortCidrBlocks(vpcCidrs, allocated[zoneId]); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	// The subnets are handed out in the order of zone_ids. Each zone keeps up to count of its allocated cidr
+	// blocks first, and its remaining slots get free cidr blocks found after the last of them, so the cidr
+	// blocks of a zone keep their index when subnet_count grows or a lower block becomes free.
+	var cidrs []string
+	var s []map[string]interface{}
+	for _, zoneId := range zoneIds {
+		n := count
+		if len(allocated[zoneId]) < n {
+			n = len(allocated[zoneId])
+		}
+		blocks := append([]string{}, allocated[zoneId][:n]...)
+		allocated[zoneId] = allocated[zoneId][n:]
+		if missing := count - n; missing > 0 {
+			after := ""
+			if n > 0 {
+				after = blocks[n-1]
+			}
+			free, err := allocateVpcCidrBlocks(vpcCidrs, used, after, prefixLength, missing)
+			if err != nil {
+				return WrapErrorf(err, DataDefaultErrorMsg, "apsarastack_vpc_cidr_allocator", "AllocateCidrBlocks", ProviderERROR)
+			}
+			used = append(used, free...)
+			blocks = append(blocks, free...)
+		}
+		for _, cidr := range blocks {
+			cidrs = append(cidrs, cidr)
+			s = append(s, map[string]interface{}{
+				"zone_id":    zoneId,
+				"cidr_block": cidr,
+			})
+		}
+	}
+
+	d.SetId(dataResourceIdHash(append([]string{vpcId}, cidrs...)))
+	if err := d.Set("cidr_blocks", cidrs); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("subnets", s); err != nil {
+		return WrapError(err)
+	}
+
+	// create a json file in current directory and write data source to it.
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		writeToFile(output.(string), s)
+	}
+	return nil
+}
+
+type ipv4Range struct {
+	first, last uint32
+}
+
+func parseIpv4Range(cidr string) (ipv4Range, error) {
+	_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+	if err != nil {
+		return ipv4Range{}, err
+	}
+	ip := ipNet.IP.To4()
+	if ip == nil {
+		return ipv4Range{}, fmt.Errorf("%s is not an IPv4 cidr block", cidr)
+	}
+	ones, bits := ipNet.Mask.Size()
+	first := binary.BigEndian.Uint32(ip)
+	return ipv4Range{first: first, last: first | uint32(1<<uint(bits-ones)-1)}, nil
+}
+
+// cidrBlockPosition returns the index of the VPC cidr block that contains cidr and its first address, which is
+// the order in which allocateVpcCidrBlocks reaches it.
+func cidrBlockPosition(vpcCidrs []string, cidr string) (int, uint32, error) {
+	r, err := parseIpv4Range(cidr)
+	if err != nil {
+		return 0, 0, err
+	}
+	for i, vpcCidr := range vpcCidrs {
+		if vpcCidr == "" {
+			continue
+		}
+		vpcRange, err := parseIpv4Range(vpcCidr)
+		if err != nil {
+			return 0, 0, err
+		}
+		if vpcRange.first <= r.first && r.last <= vpcRange.last {
+			return i, r.first, nil
+		}
+	}
+	return len(vpcCidrs), r.first, nil
+}
+
+// sortCidrBlocks sorts cidrs in the order allocateVpcCidrBlocks hands them out.
+func sortCidrBlocks(vpcCidrs, cidrs []string) error {
+	type position struct {
+		index int
+		first uint32
+	}
+	positions := make(map[string]position, len(cidrs))
+	for _, cidr := range cidrs {
+		index, first, err := cidrBlockPosition(vpcCidrs, cidr)
+		if err != nil {
+			return err
+		}
+		positions[cidr] = position{index, first}
+	}
+	sort.Slice(cidrs, func(i, j int) bool {
+		pi, pj := positions[cidrs[i]], positions[cidrs[j]]
+		return pi.index < pj.index || (pi.index == pj.index && pi.first < pj.first)
+	})
+	return nil
+}
+
+// allocateVpcCidrBlocks returns the first count cidr blocks with the given prefix length that lie inside
+// vpcCidrs and do not overlap usedCidrs. The VPC cidr blocks are searched in the given order and each of
+// them from its lowest address, so the same input always gives the same result. When after is set, the
+// search starts behind it.
+func allocateVpcCidrBlocks(vpcCidrs, usedCidrs []string, after string, prefixLength, count int) ([]string, error) {
+	var used []ipv4Range
+	for _, cidr := range usedCidrs {
+		if cidr == "" {
+			continue
+		}
+		r, err := parseIpv4Range(cidr)
+		if err != nil {
+			return nil, err
+		}
+		used = append(used, r)
+	}
+	afterIndex, afterLast := -1, uint64(0)
+	if after != "" {
+		index, _, err := cidrBlockPosition(vpcCidrs, after)
+		if err != nil {
+			return nil, err
+		}
+		r, _ := parseIpv4Range(after)
+		afterIndex, afterLast = index, uint64(r.last)
+	}
+
+	size := uint64(1) << uint(32-prefixLength)
+	var result []string
+	for i, vpcCidr := range vpcCidrs {
+		if vpcCidr == "" || i < afterIndex {
+			continue
+		}
+		vpcRange, err := parseIpv4Range(vpcCidr)
+		if err != nil {
+			return nil, err
+		}
+		next := uint64(vpcRange.first)
+		if i == afterIndex && afterLast >= next {
+			next = (afterLast + size) / size * size
+		}
+		for len(result) < count && next+size-1 <= uint64(vpcRange.last) {
+			candidate := ipv4Range{first: uint32(next), last: uint32(next + size - 1)}
+			overlap := false
+			for _, u := range used {
+				if u.first <= candidate.last && candidate.first <= u.last {
+					// Skip to the first aligned block after the used one.
+					next = (uint64(u.last) + size) / size * size
+					overlap = true
+					break
+				}
+			}
+			if overlap {
+				continue
+			}
+			ip := make(net.IP, net.IPv4len)
+			binary.BigEndian.PutUint32(ip, candidate.first)
+			result = append(result, fmt.Sprintf("%s/%d", ip.String(), prefixLength))
+			used = append(used, candidate)
+			next += size
+		}
+	}
+	if len(result) < count {
+		return nil, fmt.Errorf("only %d free /%d cidr blocks are left in %s, %d are requested", len(result), prefixLength, strings.Join(vpcCidrs, ","), count)
+	}
+	return result, nil
+}
@@ -0,0 +1,121 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackVpcCidrAllocatorDataSourceBasic(t *testing.T) {
+	rand := acctest.RandIntRange(10000, 99999)
+	resourceId := "data.apsarastack_vpc_cidr_allocator.default"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckApsaraStackVpcCidrAllocatorDataSourceConfig(rand, `
+  prefix_length = 21
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "cidr_blocks.#", "1"),
+					resource.TestCheckResourceAttr(resourceId, "cidr_blocks.0", "172.16.8.0/21"),
+					resource.TestCheckResourceAttr(resourceId, "subnets.#", "1"),
+					resource.TestCheckResourceAttr(resourceId, "subnets.0.zone_id", ""),
+				),
+			},
+			{
+				Config: testAccCheckApsaraStackVpcCidrAllocatorDataSourceConfig(rand, `
+  prefix_length       = 24
+  zone_ids            = ["${data.apsarastack_zones.default.zones.0.id}", "zone-b"]
+  subnet_count        = 2
+  exclude_cidr_blocks = ["172.16.8.0/24"]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "cidr_blocks.#", "4"),
+					resource.TestCheckResourceAttr(resourceId, "subnets.0.cidr_block", "172.16.9.0/24"),
+					resource.TestCheckResourceAttr(resourceId, "subnets.1.cidr_block", "172.16.10.0/24"),
+					resource.TestCheckResourceAttr(resourceId, "subnets.2.cidr_block", "172.16.11.0/24"),
+					resource.TestCheckResourceAttr(resourceId, "subnets.2.zone_id", "zone-b"),
+					resource.TestCheckResourceAttr(resourceId, "subnets.3.cidr_block", "172.16.12.0/24"),
+				),
+			},
+			{
+				Config: testAccCheckApsaraStackVpcCidrAllocatorDataSourceVSwitchConfig(rand, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "cidr_blocks.#", "2"),
+					resource.TestCheckResourceAttr("apsarastack_vswitch.allocated.0", "cidr_block", "172.16.8.0/24"),
+					resource.TestCheckResourceAttr("apsarastack_vswitch.allocated.1", "cidr_block", "172.16.9.0/24"),
+				),
+			},
+			{
+				// The vSwitches created from the result must not change it.
+				Config:   testAccCheckApsaraStackVpcCidrAllocatorDataSourceVSwitchConfig(rand, 2),
+				PlanOnly: true,
+			},
+			{
+				// A larger subnet_count appends a cidr block and keeps the allocated ones at their index.
+				Config: testAccCheckApsaraStackVpcCidrAllocatorDataSourceVSwitchConfig(rand, 3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "subnets.#", "3"),
+					resource.TestCheckResourceAttr(resourceId, "subnets.0.cidr_block", "172.16.8.0/24"),
+					resource.TestCheckResourceAttr(resourceId, "subnets.1.cidr_block", "172.16.9.0/24"),
+					resource.TestCheckResourceAttr(resourceId, "subnets.2.cidr_block", "172.16.10.0/24"),
+					resource.TestCheckResourceAttr("apsarastack_vswitch.allocated.0", "cidr_block", "172.16.8.0/24"),
+					resource.TestCheckResourceAttr("apsarastack_vswitch.allocated.1", "cidr_block", "172.16.9.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckApsaraStackVpcCidrAllocatorDataSourceConfig(rand int, args string) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "tf-testAccVpcCidrAllocator%d"
+}
+
+data "apsarastack_zones" "default" {
+  available_resource_creation = "VSwitch"
+}
+
+resource "apsarastack_vpc" "default" {
+  name       = "${var.name}"
+  cidr_block = "172.16.0.0/16"
+}
+
+resource "apsarastack_vswitch" "default" {
+  name              = "${var.name}"
+  vpc_id            = "${apsarastack_vpc.default.id}"
+  cidr_block        = "172.16.0.0/21"
+  availability_zone = "${data.apsarastack_zones.default.zones.0.id}"
+}
+
+data "apsarastack_vpc_cidr_allocator" "default" {
+  vpc_id = "${apsarastack_vswitch.default.vpc_id}"
+%s
+}
+`, rand, args)
+}
+
+func testAccCheckApsaraStackVpcCidrAllocatorDataSourceVSwitchConfig(rand, subnetCount int) string {
+	return testAccCheckApsaraStackVpcCidrAllocatorDataSourceConfig(rand, fmt.Sprintf(`
+  prefix_length      = 24
+  zone_ids           = ["${data.apsarastack_zones.default.zones.0.id}"]
+  subnet_count       = %d
+  vswitch_name_regex = "^${var.name}-allocated"
+`, subnetCount)) + `
+resource "apsarastack_vswitch" "allocated" {
+  count             = 2
+  name              = "${var.name}-allocated"
+  vpc_id            = "${apsarastack_vpc.default.id}"
+  cidr_block        = "${lookup(data.apsarastack_vpc_cidr_allocator.default.subnets[count.index], "cidr_block")}"
+  availability_zone = "${lookup(data.apsarastack_vpc_cidr_allocator.default.subnets[count.index], "zone_id")}"
+}
+`
+}
@@ -0,0 +1,257 @@
+package apsarastack
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceApsaraStackVpcFlowLogs() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceApsaraStackVpcFlowLogsRead,
+
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+				ForceNew: true,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ValidateRegexp,
+				ForceNew:     true,
+			},
+			"resource_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"resource_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"VPC", "VSwitch", "NetworkInterface"}, false),
+			},
+			"traffic_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"All", "Allow", "Drop"}, false),
+			},
+			"project_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"log_store_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{string(Active), string(Inactive)}, false),
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// Computed values
+			"logs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"flow_log_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"traffic_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"project_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"log_store_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"creation_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceApsaraStackVpcFlowLogsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	request := vpc.CreateDescribeFlowLogsRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	if v, ok := d.GetOk("resource_id"); ok {
+		request.ResourceId = v.(string)
+	}
+	if v, ok := d.GetOk("resource_type"); ok {
+		request.ResourceType = v.(string)
+	}
+	if v, ok := d.GetOk("traffic_type"); ok {
+		request.TrafficType = v.(string)
+	}
+	if v, ok := d.GetOk("project_name"); ok {
+		request.ProjectName = v.(string)
+	}
+	if v, ok := d.GetOk("log_store_name"); ok {
+		request.LogStoreName = v.(string)
+	}
+	if v, ok := d.GetOk("status"); ok {
+		request.Status = v.(string)
+	}
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+
+	idsMap := make(map[string]string)
+	if v, ok := d.GetOk("ids"); ok {
+		for _, vv := range v.([]interface{}) {
+			idsMap[Trim(vv.(string))] = Trim(vv.(string))
+		}
+	}
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		r, err := regexp.Compile(v.(string))
+		if err != nil {
+			return WrapError(err)
+		}
+		nameRegex = r
+	}
+
+	var allFlowLogs []vpc.FlowLog
+	invoker := NewInvoker()
+	for {
+		var raw interface{}
+		if err := invoker.Run(func() error {
+			response, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+				return vpcClient.DescribeFlowLogs(request)
+			})
+			raw = response
+			return err
+		}); err != nil {
+			return WrapErrorf(err, DataDefaultErrorMsg, "apsarastack_vpc_flow_logs", request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*vpc.DescribeFlowLogsResponse)
+		if len(response.FlowLogs.FlowLog) < 1 {
+			break
+		}
+
+		for _, flowLog := range response.FlowLogs.FlowLog {
+			if nameRegex != nil && !nameRegex.MatchString(flowLog.FlowLogName) {
+				continue
+			}
+			if len(idsMap) > 0 {
+				if _, ok := idsMap[flowLog.FlowLogId]; !ok {
+					continue
+				}
+			}
+			allFlowLogs = append(allFlowLogs, flowLog)
+		}
+
+		if len(response.FlowLogs.FlowLog) < PageSizeLarge {
+			break
+		}
+		page, err := getNextpageNumber(request.PageNumber)
+		if err != nil {
+			return WrapError(err)
+		}
+		request.PageNumber = page
+	}
+
+	return vpcFlowLogsDescriptionAttributes(d, allFlowLogs)
+}
+
+func vpcFlowLogsDescriptionAttributes(d *schema.ResourceData, flowLogs []vpc.FlowLog) error {
+	var ids []string
+	var names []string
+	var s []map[string]interface{}
+	for _, flowLog := range flowLogs {
+		mapping := map[string]interface{}{
+			"id":             flowLog.FlowLogId,
+			"flow_log_name":  flowLog.FlowLogName,
+			"description":    flowLog.Description,
+			"resource_id":    flowLog.ResourceId,
+			"resource_type":  flowLog.ResourceType,
+			"traffic_type":   flowLog.TrafficType,
+			"project_name":   flowLog.ProjectName,
+			"log_store_name": flowLog.LogStoreName,
+			"status":         flowLog.Status,
+			"creation_time":  flowLog.CreationTime,
+		}
+		ids = append(ids, flowLog.FlowLogId)
+		names = append(names, flowLog.FlowLogName)
+		s = append(s, mapping)
+	}
+
+	d.SetId(dataResourceIdHash(ids))
+	if err := d.Set("logs", s); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("names", names); err != nil {
+		return WrapError(err)
+	}
+
+	// create a json file in current directory and write data source to it.
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		writeToFile(output.(string), s)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+)
+
+func TestAccApsaraStackVpcFlowLogsDataSourceBasic(t *testing.T) {
+	rand := acctest.RandIntRange(10000, 99999)
+
+	idsConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackVpcFlowLogsDataSourceConfig(rand, map[string]string{
+			"ids": `["${apsarastack_vpc_flow_log.default.id}"]`,
+		}),
+		fakeConfig: testAccCheckApsaraStackVpcFlowLogsDataSourceConfig(rand, map[string]string{
+			"ids": `["${apsarastack_vpc_flow_log.default.id}_fake"]`,
+		}),
+	}
+	nameRegexConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackVpcFlowLogsDataSourceConfig(rand, map[string]string{
+			"name_regex": `"${apsarastack_vpc_flow_log.default.flow_log_name}"`,
+		}),
+		fakeConfig: testAccCheckApsaraStackVpcFlowLogsDataSourceConfig(rand, map[string]string{
+			"name_regex": `"${apsarastack_vpc_flow_log.default.flow_log_name}_fake"`,
+		}),
+	}
+	resourceIdConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackVpcFlowLogsDataSourceConfig(rand, map[string]string{
+			"resource_id": `"${apsarastack_vpc_flow_log.default.resource_id}"`,
+			"status":      `"Active"`,
+		}),
+		fakeConfig: testAccCheckApsaraStackVpcFlowLogsDataSourceConfig(rand, map[string]string{
+			"resource_id": `"${apsarastack_vpc_flow_log.default.resource_id}"`,
+			"status":      `"Inactive"`,
+		}),
+	}
+	vpcFlowLogsCheckInfo.dataSourceTestCheck(t, rand, idsConf, nameRegexConf, resourceIdConf)
+}
+
+func testAccCheckApsaraStackVpcFlowLogsDataSourceConfig(rand int, attrMap map[string]string) string {
+	var pairs []string
+	for k, v := range attrMap {
+		pairs = append(pairs, k+" = "+v)
+	}
+
+	config := fmt.Sprintf(`
+%s
+
+data "apsarastack_vpc_flow_logs" "default" {
+  %s
+}
+`, testAccVpcFlowLogConfig(rand, fmt.Sprintf("tf-testAccVpcFlowLog%d", rand), "Active"), strings.Join(pairs, "\n  "))
+	return config
+}
+
+var existsVpcFlowLogsMapFunc = func(rand int) map[string]string {
+	return map[string]string{
+		"ids.#":                 "1",
+		"names.#":               "1",
+		"logs.#":                "1",
+		"logs.0.id":             CHECKSET,
+		"logs.0.flow_log_name":  fmt.Sprintf("tf-testAccVpcFlowLog%d", rand),
+		"logs.0.resource_id":    CHECKSET,
+		"logs.0.resource_type":  "VPC",
+		"logs.0.traffic_type":   "All",
+		"logs.0.project_name":   fmt.Sprintf("tf-testacc-flowlog-%d", rand),
+		"logs.0.log_store_name": fmt.Sprintf("tf-testacc-flowlog-%d", rand),
+		"logs.0.status":         "Active",
+	}
+}
+
+var fakeVpcFlowLogsMapFunc = func(rand int) map[string]string {
+	return map[string]string{
+		"ids.#":   "0",
+		"names.#": "0",
+		"logs.#":  "0",
+	}
+}
+
+var vpcFlowLogsCheckInfo = dataSourceAttr{
+	resourceId:   "data.apsarastack_vpc_flow_logs.default",
+	existMapFunc: existsVpcFlowLogsMapFunc,
+	fakeMapFunc:  fakeVpcFlowLogsMapFunc,
+}
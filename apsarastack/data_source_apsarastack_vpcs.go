@@ -45,6 +45,7 @@ func dataSourceApsaraStackVpcs() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"tags": tagsSchema(),
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -123,6 +124,7 @@ func dataSourceApsaraStackVpcs() *schema.Resource {
 }
 func dataSourceApsaraStackVpcsRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
 
 	request := vpc.CreateDescribeVpcsRequest()
 	if strings.ToLower(client.Config.Protocol) == "https" {
@@ -210,6 +212,15 @@ func dataSourceApsaraStackVpcsRead(d *schema.ResourceData, meta interface{}) err
 		if vswitchId, ok := d.GetOk("vswitch_id"); ok && !vpcVswitchIdListContains(v.VSwitchIds.VSwitchId, vswitchId.(string)) {
 			continue
 		}
+		if value, ok := d.GetOk("tags"); ok && len(value.(map[string]interface{})) > 0 {
+			tags, err := vpcService.DescribeTags(v.VpcId, value.(map[string]interface{}), TagResourceVpc)
+			if err != nil {
+				return WrapError(err)
+			}
+			if len(tags) < 1 {
+				continue
+			}
+		}
 		request := vpc.CreateDescribeVRoutersRequest()
 		request.RegionId = client.RegionId
 		if strings.ToLower(client.Config.Protocol) == "https" {
@@ -0,0 +1,177 @@
+package apsarastack
+
+import (
+	"strings"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceApsaraStackVpnConnectionConfig() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceApsaraStackVpnConnectionConfigRead,
+
+		Schema: map[string]*schema.Schema{
+			"vpn_connection_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// Computed values, seen from the customer side of the tunnel.
+			"local": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"remote": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"local_subnet": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"remote_subnet": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"ike_config": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"psk": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"ike_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ike_mode": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ike_enc_alg": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ike_auth_alg": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ike_pfs": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ike_lifetime": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"ike_local_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ike_remote_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"ipsec_config": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ipsec_enc_alg": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ipsec_auth_alg": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ipsec_pfs": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ipsec_lifetime": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceApsaraStackVpnConnectionConfigRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpnConnectionId := d.Get("vpn_connection_id").(string)
+
+	request := vpc.CreateDownloadVpnConnectionConfigRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.VpnConnectionId = vpnConnectionId
+
+	var raw interface{}
+	invoker := NewInvoker()
+	if err := invoker.Run(func() error {
+		response, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DownloadVpnConnectionConfig(request)
+		})
+		raw = response
+		return err
+	}); err != nil {
+		return WrapErrorf(err, DataDefaultErrorMsg, "apsarastack_vpn_connection_config", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	response, _ := raw.(*vpc.DownloadVpnConnectionConfigResponse)
+	config := response.VpnConnectionConfig
+
+	d.SetId(vpnConnectionId)
+	d.Set("local", config.Local)
+	d.Set("remote", config.Remote)
+	if err := d.Set("local_subnet", strings.Split(config.LocalSubnet, ",")); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("remote_subnet", strings.Split(config.RemoteSubnet, ",")); err != nil {
+		return WrapError(err)
+	}
+	ikeConfig := flattenApsaraStackVpnIkeConfig(config.IkeConfig)
+	if err := d.Set("ike_config", ikeConfig); err != nil {
+		return WrapError(err)
+	}
+	ipsecConfig := flattenApsaraStackVpnIpsecConfig(config.IpsecConfig)
+	if err := d.Set("ipsec_config", ipsecConfig); err != nil {
+		return WrapError(err)
+	}
+
+	// create a json file in current directory and write data source to it.
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		writeToFile(output.(string), map[string]interface{}{
+			"local":         config.Local,
+			"remote":        config.Remote,
+			"local_subnet":  config.LocalSubnet,
+			"remote_subnet": config.RemoteSubnet,
+			"ike_config":    ikeConfig,
+			"ipsec_config":  ipsecConfig,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+)
+
+func TestAccApsaraStackVpnConnectionConfigDataSourceBasic(t *testing.T) {
+	rand := acctest.RandIntRange(1000, 9999)
+
+	connectionConf := dataSourceTestAccConfig{
+		existConfig: testAccCheckApsaraStackVpnConnectionConfigDataSourceConfig(rand),
+	}
+	vpnConnectionConfigCheckInfo.dataSourceTestCheck(t, rand, connectionConf)
+}
+
+func testAccCheckApsaraStackVpnConnectionConfigDataSourceConfig(rand int) string {
+	return fmt.Sprintf(`
+%s
+
+data "apsarastack_vpn_connection_config" "default" {
+  vpn_connection_id = "${apsarastack_vpn_connection.default.id}"
+}
+`, testAccVpnConnectionConfig(rand, "aes", "group2"))
+}
+
+var existsVpnConnectionConfigMapFunc = func(rand int) map[string]string {
+	return map[string]string{
+		"local":                         "42.104.22.210",
+		"remote":                        CHECKSET,
+		"local_subnet.#":                "1",
+		"local_subnet.0":                "192.168.1.0/24",
+		"remote_subnet.#":               "1",
+		"remote_subnet.0":               "172.16.0.0/24",
+		"ike_config.#":                  "1",
+		"ike_config.0.psk":              "tf-testvpn1",
+		"ike_config.0.ike_enc_alg":      "aes",
+		"ike_config.0.ike_pfs":          "group2",
+		"ipsec_config.#":                "1",
+		"ipsec_config.0.ipsec_enc_alg":  "aes",
+		"ipsec_config.0.ipsec_pfs":      "group2",
+		"ipsec_config.0.ipsec_lifetime": "86400",
+	}
+}
+
+// The configuration is looked up by id, so there is no fake config to check against.
+var fakeVpnConnectionConfigMapFunc = func(rand int) map[string]string {
+	return map[string]string{}
+}
+
+var vpnConnectionConfigCheckInfo = dataSourceAttr{
+	resourceId:   "data.apsarastack_vpn_connection_config.default",
+	existMapFunc: existsVpnConnectionConfigMapFunc,
+	fakeMapFunc:  fakeVpnConnectionConfigMapFunc,
+}
@@ -43,6 +43,7 @@ func dataSourceApsaraStackVSwitches() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"tags": tagsSchema(),
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -109,6 +110,7 @@ func dataSourceApsaraStackVSwitches() *schema.Resource {
 }
 func dataSourceApsaraStackVSwitchesRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
 
 	request := vpc.CreateDescribeVSwitchesRequest()
 	if strings.ToLower(client.Config.Protocol) == "https" {
@@ -185,6 +187,15 @@ func dataSourceApsaraStackVSwitchesRead(d *schema.ResourceData, meta interface{}
 					continue
 				}
 			}
+			if value, ok := d.GetOk("tags"); ok && len(value.(map[string]interface{})) > 0 {
+				tags, err := vpcService.DescribeTags(vsw.VSwitchId, value.(map[string]interface{}), TagResourceVSwitch)
+				if err != nil {
+					return WrapError(err)
+				}
+				if len(tags) < 1 {
+					continue
+				}
+			}
 			allVSwitches = append(allVSwitches, vsw)
 		}
 
@@ -297,3 +297,11 @@ func cmsClientCriticalSuppressFunc(k, old, new string, d *schema.ResourceData) b
 	}
 	return false
 }
+
+func userDataDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	return userDataHashSum(old) == userDataHashSum(new)
+}
+
+func vpnSslConnectionsDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	return !d.Get("enable_ssl").(bool)
+}
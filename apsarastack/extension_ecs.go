@@ -81,3 +81,6 @@ const (
 )
 
 type CreditSpecification string
+
+// The number of console output lines attached to the error when an instance fails to become Running.
+const InstanceConsoleOutputErrorLines = 50
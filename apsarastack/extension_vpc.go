@@ -8,6 +8,7 @@ const (
 )
 
 type RouterType string
+type NextHopType string
 type Role string
 type Spec string
 
@@ -34,6 +35,37 @@ const (
 	Negative = Spec(("Negative"))
 )
 
+const (
+	NextHopInstance         = NextHopType("Instance")
+	NextHopHaVip            = NextHopType("HaVip")
+	NextHopRouterInterface  = NextHopType("RouterInterface")
+	NextHopNetworkInterface = NextHopType("NetworkInterface")
+	NextHopVpnGateway       = NextHopType("VpnGateway")
+	NextHopIPv6Gateway      = NextHopType("IPv6Gateway")
+	NextHopNatGateway       = NextHopType("NatGateway")
+
+	// NextHopEcmp is reported for route entries with several next hops.
+	NextHopEcmp = NextHopType("Ecmp")
+)
+
+// routeEntryNextHopIdPrefix maps each supported next hop type to the prefix of its instance ID.
+var routeEntryNextHopIdPrefix = map[NextHopType]string{
+	NextHopInstance:         "i-",
+	NextHopHaVip:            "havip-",
+	NextHopRouterInterface:  "ri-",
+	NextHopNetworkInterface: "eni-",
+	NextHopVpnGateway:       "vpn-",
+	NextHopIPv6Gateway:      "ipv6gw-",
+	NextHopNatGateway:       "ngw-",
+}
+
+func GetAllRouteEntryNextHopType() (types []string) {
+	types = append(types, string(NextHopInstance), string(NextHopHaVip),
+		string(NextHopRouterInterface), string(NextHopNetworkInterface),
+		string(NextHopVpnGateway), string(NextHopIPv6Gateway), string(NextHopNatGateway))
+	return
+}
+
 func GetAllRouterInterfaceSpec() (specifications []string) {
 	specifications = append(specifications, string(Mini2), string(Mini5),
 		string(Small1), string(Small2), string(Small5),
@@ -188,16 +188,20 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("APSARASTACK_QUICKBI_ENDPOINT", nil),
 				Description: descriptions["quickbi_endpoint"],
 			},
+			"default_disk_encryption": defaultDiskEncryptionSchema(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"apsarastack_account":                              dataSourceApsaraStackAccount(),
 			"apsarastack_ess_scaling_configurations":           dataSourceApsaraStackEssScalingConfigurations(),
 			"apsarastack_instances":                            dataSourceApsaraStackInstances(),
+			"apsarastack_ecs_instance_console_output":          dataSourceApsaraStackEcsInstanceConsoleOutput(),
 			"apsarastack_disks":                                dataSourceApsaraStackDisks(),
 			"apsarastack_key_pairs":                            dataSourceApsaraStackKeyPairs(),
+			"apsarastack_launch_templates":                     dataSourceApsaraStackLaunchTemplates(),
 			"apsarastack_network_interfaces":                   dataSourceApsaraStackNetworkInterfaces(),
 			"apsarastack_instance_type_families":               dataSourceApsaraStackInstanceTypeFamilies(),
 			"apsarastack_instance_types":                       dataSourceApsaraStackInstanceTypes(),
+			"apsarastack_instance_type_recommendation":         dataSourceApsaraStackInstanceTypeRecommendation(),
 			"apsarastack_security_groups":                      dataSourceApsaraStackSecurityGroups(),
 			"apsarastack_security_group_rules":                 dataSourceApsaraStackSecurityGroupRules(),
 			"apsarastack_snapshots":                            dataSourceApsaraStackSnapshots(),
@@ -205,6 +209,7 @@ func Provider() terraform.ResourceProvider {
 			"apsarastack_vswitches":                            dataSourceApsaraStackVSwitches(),
 			"apsarastack_vpcs":                                 dataSourceApsaraStackVpcs(),
 			"apsarastack_eips":                                 dataSourceApsaraStackEips(),
+			"apsarastack_eip_address_pool":                     dataSourceApsaraStackEipAddressPool(),
 			"apsarastack_slb_listeners":                        dataSourceApsaraStackSlbListeners(),
 			"apsarastack_slb_server_groups":                    dataSourceApsaraStackSlbServerGroups(),
 			"apsarastack_slb_acls":                             dataSourceApsaraStackSlbAcls(),
@@ -216,6 +221,13 @@ func Provider() terraform.ResourceProvider {
 			"apsarastack_slbs":                                 dataSourceApsaraStackSlbs(),
 			"apsarastack_slb_zones":                            dataSourceApsaraStackSlbZones(),
 			"apsarastack_common_bandwidth_packages":            dataSourceApsaraStackCommonBandwidthPackages(),
+			"apsarastack_cen_instances":                        dataSourceApsaraStackCenInstances(),
+			"apsarastack_cen_instance_attachments":             dataSourceApsaraStackCenInstanceAttachments(),
+			"apsarastack_cen_bandwidth_packages":               dataSourceApsaraStackCenBandwidthPackages(),
+			"apsarastack_cen_route_entries":                    dataSourceApsaraStackCenRouteEntries(),
+			"apsarastack_vpn_connection_config":                dataSourceApsaraStackVpnConnectionConfig(),
+			"apsarastack_vpc_flow_logs":                        dataSourceApsaraStackVpcFlowLogs(),
+			"apsarastack_vpc_cidr_allocator":                   dataSourceApsaraStackVpcCidrAllocator(),
 			"apsarastack_forward_entries":                      dataSourceApsaraStackForwardEntries(),
 			"apsarastack_nat_gateways":                         dataSourceApsaraStackNatGateways(),
 			"apsarastack_snat_entries":                         dataSourceApsaraStackSnatEntries(),
@@ -304,7 +316,9 @@ func Provider() terraform.ResourceProvider {
 			"apsarastack_ram_role_attachment":                  resourceApsaraStackRamRoleAttachment(),
 			"apsarastack_security_group":                       resourceApsaraStackSecurityGroup(),
 			"apsarastack_security_group_rule":                  resourceApsaraStackSecurityGroupRule(),
+			"apsarastack_security_group_rules":                 resourceApsaraStackSecurityGroupRules(),
 			"apsarastack_launch_template":                      resourceApsaraStackLaunchTemplate(),
+			"apsarastack_launch_template_version":              resourceApsaraStackLaunchTemplateVersion(),
 			"apsarastack_reserved_instance":                    resourceApsaraStackReservedInstance(),
 			"apsarastack_image":                                resourceApsaraStackImage(),
 			"apsarastack_image_export":                         resourceApsaraStackImageExport(),
@@ -329,9 +343,24 @@ func Provider() terraform.ResourceProvider {
 			"apsarastack_slb":                                  resourceApsaraStackSlb(),
 			"apsarastack_common_bandwidth_package":             resourceApsaraStackCommonBandwidthPackage(),
 			"apsarastack_common_bandwidth_package_attachment":  resourceApsaraStackCommonBandwidthPackageAttachment(),
+			"apsarastack_cen_instance":                         resourceApsaraStackCenInstance(),
+			"apsarastack_cen_instance_attachment":              resourceApsaraStackCenInstanceAttachment(),
+			"apsarastack_cen_instance_grant":                   resourceApsaraStackCenInstanceGrant(),
+			"apsarastack_cen_bandwidth_package":                resourceApsaraStackCenBandwidthPackage(),
+			"apsarastack_cen_bandwidth_package_attachment":     resourceApsaraStackCenBandwidthPackageAttachment(),
+			"apsarastack_cen_route_entry":                      resourceApsaraStackCenRouteEntry(),
+			"apsarastack_vpn_gateway":                          resourceApsaraStackVpnGateway(),
+			"apsarastack_vpn_customer_gateway":                 resourceApsaraStackVpnCustomerGateway(),
+			"apsarastack_vpn_connection":                       resourceApsaraStackVpnConnection(),
+			"apsarastack_vpn_route_entry":                      resourceApsaraStackVpnRouteEntry(),
+			"apsarastack_vpc_flow_log":                         resourceApsaraStackVpcFlowLog(),
+			"apsarastack_havip":                                resourceApsaraStackHaVip(),
+			"apsarastack_havip_attachment":                     resourceApsaraStackHaVipAttachment(),
 			"apsarastack_forward_entry":                        resourceApsaraStackForwardEntry(),
 			"apsarastack_nat_gateway":                          resourceApsaraStackNatGateway(),
 			"apsarastack_snat_entry":                           resourceApsaraStackSnatEntry(),
+			"apsarastack_nat_forward_table":                    resourceApsaraStackNatForwardTable(),
+			"apsarastack_nat_snat_table":                       resourceApsaraStackNatSnatTable(),
 			"apsarastack_db_instance":                          resourceApsaraStackDBInstance(),
 			"apsarastack_db_account":                           resourceApsaraStackDBAccount(),
 			"apsarastack_db_account_privilege":                 resourceApsaraStackDBAccountPrivilege(),
@@ -424,6 +453,11 @@ func Provider() terraform.ResourceProvider {
 			"apsarastack_network_acl_entries":     resourceApsaraStackNetworkAclEntries(),
 			"apsarastack_kvstore_connection":      resourceApsaraStackKvstoreConnection(),
 			"apsarastack_ecs_deployment_set":      resourceApsaraStackEcsDeploymentSet(),
+			"apsarastack_ecs_command":             resourceApsaraStackEcsCommand(),
+			"apsarastack_ecs_invocation":          resourceApsaraStackEcsInvocation(),
+			"apsarastack_ecs_snapshot_group":      resourceApsaraStackEcsSnapshotGroup(),
+			"apsarastack_auto_provisioning_group": resourceApsaraStackAutoProvisioningGroup(),
+			"apsarastack_image_pipeline":          resourceApsaraStackImagePipeline(),
 			"apsarastack_ros_stack":               resourceApsaraStackRosStack(),
 			"apsarastack_ros_template":            resourceApsaraStackRosTemplate(),
 			"apsarastack_dms_enterprise_instance": resourceApsaraStackDmsEnterpriseInstance(),
@@ -480,6 +514,9 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		SourceIp:             strings.TrimSpace(d.Get("source_ip").(string)),
 		SecureTransport:      strings.TrimSpace(d.Get("secure_transport").(string)),
 	}
+	if v, ok := d.GetOk("default_disk_encryption"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		config.DefaultDiskKMSKeyId = strings.TrimSpace(v.([]interface{})[0].(map[string]interface{})["kms_key_id"].(string))
+	}
 	token := getProviderConfig(d.Get("security_token").(string), "sts_token")
 	config.SecurityToken = strings.TrimSpace(token)
 
@@ -536,6 +573,7 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		config.LogEndpoint = domain
 		config.CrEndpoint = domain
 		config.EssEndpoint = domain
+		config.CenEndpoint = domain
 		config.DnsEndpoint = domain
 		config.KVStoreEndpoint = domain
 		config.GpdbEndpoint = domain
@@ -564,6 +602,8 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 			config.SlbEndpoint = strings.TrimSpace(endpoints["slb"].(string))
 			config.CrEndpoint = strings.TrimSpace(endpoints["cr"].(string))
 			config.EssEndpoint = strings.TrimSpace(endpoints["ess"].(string))
+			config.CenEndpoint = strings.TrimSpace(endpoints["cen"].(string))
+			config.CbnEndpoint = strings.TrimSpace(endpoints["cbn"].(string))
 			config.DnsEndpoint = strings.TrimSpace(endpoints["dns"].(string))
 			config.KVStoreEndpoint = strings.TrimSpace(endpoints["kvstore"].(string))
 			config.GpdbEndpoint = strings.TrimSpace(endpoints["gpdb"].(string))
@@ -651,6 +691,8 @@ func init() {
 		"proxy": "Use this to set proxy connection",
 
 		"domain": "Use this to override the default domain. It's typically used to connect to custom domain.",
+
+		"default_disk_encryption": "The encryption applied to every disk created by the provider which does not specify its own encryption.",
 	}
 }
 func endpointsSchema() *schema.Schema {
@@ -1046,6 +1088,24 @@ func getConfigFromProfile(d *schema.ResourceData, ProfileKey string) (interface{
 
 	return providerConfig[ProfileKey], nil
 }
+func defaultDiskEncryptionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: descriptions["default_disk_encryption"],
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"kms_key_id": {
+					Type:        schema.TypeString,
+					Required:    true,
+					DefaultFunc: schema.EnvDefaultFunc("APSARASTACK_DEFAULT_DISK_KMS_KEY_ID", nil),
+				},
+			},
+		},
+	}
+}
+
 func assumeRoleSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeSet,
@@ -126,6 +126,13 @@ func testAccPreCheckWithMultipleAccount(t *testing.T) {
 	}
 }
 
+func testAccPreCheckDefaultDiskEncryption(t *testing.T) {
+	if v := strings.TrimSpace(os.Getenv("APSARASTACK_DEFAULT_DISK_KMS_KEY_ID")); v == "" {
+		t.Skipf("Skipping tests without APSARASTACK_DEFAULT_DISK_KMS_KEY_ID set.")
+		t.Skipped()
+	}
+}
+
 func testAccPreCheckOSSForImageImport(t *testing.T) {
 	if v := strings.TrimSpace(os.Getenv("APSARASTACK_OSS_BUCKET_FOR_IMAGE")); v == "" {
 		t.Skipf("Skipping tests without OSS_Bucket set.")
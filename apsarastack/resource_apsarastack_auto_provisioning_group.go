@@ -0,0 +1,407 @@
+package apsarastack
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceApsaraStackAutoProvisioningGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackAutoProvisioningGroupCreate,
+		Read:   resourceApsaraStackAutoProvisioningGroupRead,
+		Update: resourceApsaraStackAutoProvisioningGroupUpdate,
+		Delete: resourceApsaraStackAutoProvisioningGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"launch_template_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"launch_template_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"total_target_capacity": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"pay_as_you_go_target_capacity": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"spot_target_capacity": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"default_target_capacity_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"PayAsYouGo", "Spot"}, false),
+			},
+			"auto_provisioning_group_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringLenBetween(2, 128),
+			},
+			"auto_provisioning_group_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "maintain",
+				ValidateFunc: validation.StringInSlice([]string{"request", "maintain"}, false),
+			},
+			"spot_allocation_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "lowest-price",
+				ValidateFunc: validation.StringInSlice([]string{"lowest-price", "diversified"}, false),
+			},
+			"spot_instance_interruption_behavior": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "stop",
+				ValidateFunc: validation.StringInSlice([]string{"stop", "terminate"}, false),
+			},
+			"spot_instance_pools_to_use_count": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"pay_as_you_go_allocation_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "lowest-price",
+				ValidateFunc: validation.StringInSlice([]string{"lowest-price", "prioritized"}, false),
+			},
+			"excess_capacity_termination_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "no-termination",
+				ValidateFunc: validation.StringInSlice([]string{"no-termination", "termination"}, false),
+			},
+			"max_spot_price": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"valid_from": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"valid_until": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"terminate_instances_with_expiration": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"terminate_instances": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"launch_template_config": {
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"vswitch_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"weighted_capacity": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"max_price": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"priority": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceApsaraStackAutoProvisioningGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+
+	request := ecs.CreateCreateAutoProvisioningGroupRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.ClientToken = buildClientToken(request.GetActionName())
+	request.LaunchTemplateId = d.Get("launch_template_id").(string)
+	request.TotalTargetCapacity = d.Get("total_target_capacity").(string)
+	request.AutoProvisioningGroupType = d.Get("auto_provisioning_group_type").(string)
+	request.SpotAllocationStrategy = d.Get("spot_allocation_strategy").(string)
+	request.SpotInstanceInterruptionBehavior = d.Get("spot_instance_interruption_behavior").(string)
+	request.PayAsYouGoAllocationStrategy = d.Get("pay_as_you_go_allocation_strategy").(string)
+	request.ExcessCapacityTerminationPolicy = d.Get("excess_capacity_termination_policy").(string)
+	request.TerminateInstances = requests.NewBoolean(d.Get("terminate_instances").(bool))
+
+	if v, ok := d.GetOk("launch_template_version"); ok {
+		request.LaunchTemplateVersion = v.(string)
+	}
+	if v, ok := d.GetOk("pay_as_you_go_target_capacity"); ok {
+		request.PayAsYouGoTargetCapacity = v.(string)
+	}
+	if v, ok := d.GetOk("spot_target_capacity"); ok {
+		request.SpotTargetCapacity = v.(string)
+	}
+	if v, ok := d.GetOk("default_target_capacity_type"); ok {
+		request.DefaultTargetCapacityType = v.(string)
+	}
+	if v, ok := d.GetOk("auto_provisioning_group_name"); ok {
+		request.AutoProvisioningGroupName = v.(string)
+	}
+	if v, ok := d.GetOk("spot_instance_pools_to_use_count"); ok {
+		request.SpotInstancePoolsToUseCount = requests.NewInteger(v.(int))
+	}
+	if v, ok := d.GetOk("max_spot_price"); ok {
+		request.MaxSpotPrice = requests.NewFloat(v.(float64))
+	}
+	if v, ok := d.GetOk("description"); ok {
+		request.Description = v.(string)
+	}
+	if v, ok := d.GetOk("valid_from"); ok {
+		request.ValidFrom = v.(string)
+	}
+	if v, ok := d.GetOk("valid_until"); ok {
+		request.ValidUntil = v.(string)
+	}
+	if v, ok := d.GetOkExists("terminate_instances_with_expiration"); ok {
+		request.TerminateInstancesWithExpiration = requests.NewBoolean(v.(bool))
+	}
+
+	var configs []ecs.CreateAutoProvisioningGroupLaunchTemplateConfig
+	for _, v := range d.Get("launch_template_config").(*schema.Set).List() {
+		config := v.(map[string]interface{})
+		configs = append(configs, ecs.CreateAutoProvisioningGroupLaunchTemplateConfig{
+			InstanceType:     config["instance_type"].(string),
+			VSwitchId:        config["vswitch_id"].(string),
+			WeightedCapacity: config["weighted_capacity"].(string),
+			MaxPrice:         config["max_price"].(string),
+			Priority:         config["priority"].(string),
+		})
+	}
+	request.LaunchTemplateConfig = &configs
+
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.CreateAutoProvisioningGroup(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "apsarastack_auto_provisioning_group", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	response, _ := raw.(*ecs.CreateAutoProvisioningGroupResponse)
+	d.SetId(response.AutoProvisioningGroupId)
+
+	if err := ecsService.WaitForAutoProvisioningGroup(d.Id(), Active, DefaultTimeout); err != nil {
+		return WrapError(err)
+	}
+	return resourceApsaraStackAutoProvisioningGroupRead(d, meta)
+}
+
+func resourceApsaraStackAutoProvisioningGroupRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	object, err := ecsService.DescribeAutoProvisioningGroup(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("launch_template_id", object.LaunchTemplateId)
+	d.Set("launch_template_version", object.LaunchTemplateVersion)
+	d.Set("auto_provisioning_group_name", object.AutoProvisioningGroupName)
+	d.Set("auto_provisioning_group_type", object.AutoProvisioningGroupType)
+	d.Set("total_target_capacity", formatFloat64(object.TargetCapacitySpecification.TotalTargetCapacity))
+	d.Set("pay_as_you_go_target_capacity", formatFloat64(object.TargetCapacitySpecification.PayAsYouGoTargetCapacity))
+	d.Set("spot_target_capacity", formatFloat64(object.TargetCapacitySpecification.SpotTargetCapacity))
+	d.Set("default_target_capacity_type", object.TargetCapacitySpecification.DefaultTargetCapacityType)
+	d.Set("spot_allocation_strategy", object.SpotOptions.AllocationStrategy)
+	d.Set("spot_instance_interruption_behavior", object.SpotOptions.InstanceInterruptionBehavior)
+	d.Set("spot_instance_pools_to_use_count", object.SpotOptions.InstancePoolsToUseCount)
+	d.Set("pay_as_you_go_allocation_strategy", object.PayAsYouGoOptions.AllocationStrategy)
+	d.Set("excess_capacity_termination_policy", object.ExcessCapacityTerminationPolicy)
+	d.Set("max_spot_price", object.MaxSpotPrice)
+	d.Set("valid_from", object.ValidFrom)
+	d.Set("valid_until", object.ValidUntil)
+	d.Set("terminate_instances_with_expiration", object.TerminateInstancesWithExpiration)
+	d.Set("terminate_instances", object.TerminateInstances)
+	d.Set("status", object.Status)
+	d.Set("state", object.State)
+
+	configs := make([]map[string]interface{}, 0, len(object.LaunchTemplateConfigs.LaunchTemplateConfig))
+	for _, config := range object.LaunchTemplateConfigs.LaunchTemplateConfig {
+		priority := ""
+		if config.Priority != 0 {
+			priority = formatFloat64(config.Priority)
+		}
+		configs = append(configs, map[string]interface{}{
+			"instance_type":     config.InstanceType,
+			"vswitch_id":        config.VSwitchId,
+			"weighted_capacity": formatFloat64(config.WeightedCapacity),
+			"max_price":         formatFloat64(config.MaxPrice),
+			"priority":          priority,
+		})
+	}
+	if err := d.Set("launch_template_config", configs); err != nil {
+		return WrapError(err)
+	}
+	return nil
+}
+
+func resourceApsaraStackAutoProvisioningGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	request := ecs.CreateModifyAutoProvisioningGroupRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.AutoProvisioningGroupId = d.Id()
+
+	update := false
+	if d.HasChange("auto_provisioning_group_name") {
+		request.AutoProvisioningGroupName = d.Get("auto_provisioning_group_name").(string)
+		update = true
+	}
+	if d.HasChange("total_target_capacity") {
+		request.TotalTargetCapacity = d.Get("total_target_capacity").(string)
+		update = true
+	}
+	if d.HasChange("pay_as_you_go_target_capacity") {
+		request.PayAsYouGoTargetCapacity = d.Get("pay_as_you_go_target_capacity").(string)
+		update = true
+	}
+	if d.HasChange("spot_target_capacity") {
+		request.SpotTargetCapacity = d.Get("spot_target_capacity").(string)
+		update = true
+	}
+	if d.HasChange("default_target_capacity_type") {
+		request.DefaultTargetCapacityType = d.Get("default_target_capacity_type").(string)
+		update = true
+	}
+	if d.HasChange("excess_capacity_termination_policy") {
+		request.ExcessCapacityTerminationPolicy = d.Get("excess_capacity_termination_policy").(string)
+		update = true
+	}
+	if d.HasChange("max_spot_price") {
+		request.MaxSpotPrice = requests.NewFloat(d.Get("max_spot_price").(float64))
+		update = true
+	}
+	if d.HasChange("terminate_instances_with_expiration") {
+		request.TerminateInstancesWithExpiration = requests.NewBoolean(d.Get("terminate_instances_with_expiration").(bool))
+		update = true
+	}
+
+	if update {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifyAutoProvisioningGroup(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	}
+	return resourceApsaraStackAutoProvisioningGroupRead(d, meta)
+}
+
+func resourceApsaraStackAutoProvisioningGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+
+	request := ecs.CreateDeleteAutoProvisioningGroupRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.AutoProvisioningGroupId = d.Id()
+	request.TerminateInstances = requests.NewBoolean(d.Get("terminate_instances").(bool))
+
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DeleteAutoProvisioningGroup(request)
+	})
+	if err != nil {
+		if IsExpectedErrors(err, []string{"InvalidAutoProvisioningGroupId.NotFound"}) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	return WrapError(ecsService.WaitForAutoProvisioningGroup(d.Id(), Deleted, DefaultTimeout))
+}
+
+// formatFloat64 renders the float capacities and prices returned by the API as the strings used in the schema.
+func formatFloat64(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,118 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackAutoProvisioningGroup_basic(t *testing.T) {
+	var v ecs.AutoProvisioningGroup
+	resourceId := "apsarastack_auto_provisioning_group.default"
+	rand := acctest.RandInt()
+	name := fmt.Sprintf("tf-testAccAutoProvisioningGroup%d", rand)
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"launch_template_id":                 CHECKSET,
+		"auto_provisioning_group_type":       "maintain",
+		"excess_capacity_termination_policy": "no-termination",
+		"terminate_instances":                "false",
+		"status":                             "active",
+	})
+	serviceFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceAutoProvisioningGroupConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"launch_template_id":            "${apsarastack_launch_template.default.id}",
+					"auto_provisioning_group_name":  "${var.name}",
+					"total_target_capacity":         "2",
+					"pay_as_you_go_target_capacity": "2",
+					"spot_target_capacity":          "0",
+					"terminate_instances":           "true",
+					"launch_template_config": []map[string]interface{}{
+						{
+							"instance_type":     "${data.apsarastack_instance_types.default.instance_types.0.id}",
+							"vswitch_id":        "${apsarastack_vswitch.default.id}",
+							"weighted_capacity": "1",
+							"max_price":         "2",
+						},
+					},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"auto_provisioning_group_name":  name,
+						"total_target_capacity":         "2",
+						"pay_as_you_go_target_capacity": "2",
+						"spot_target_capacity":          "0",
+						"terminate_instances":           "true",
+						"launch_template_config.#":      "1",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"total_target_capacity":              "3",
+					"pay_as_you_go_target_capacity":      "3",
+					"excess_capacity_termination_policy": "termination",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"total_target_capacity":              "3",
+						"pay_as_you_go_target_capacity":      "3",
+						"excess_capacity_termination_policy": "termination",
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"auto_provisioning_group_name":  "${var.name}_update",
+					"total_target_capacity":         "1",
+					"pay_as_you_go_target_capacity": "1",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"auto_provisioning_group_name":  name + "_update",
+						"total_target_capacity":         "1",
+						"pay_as_you_go_target_capacity": "1",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func resourceAutoProvisioningGroupConfigDependence(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "apsarastack_launch_template" "default" {
+  name              = "${var.name}"
+  image_id          = "${data.apsarastack_images.default.images.0.id}"
+  instance_type     = "${data.apsarastack_instance_types.default.instance_types.0.id}"
+  security_group_id = "${apsarastack_security_group.default.id}"
+  vswitch_id        = "${apsarastack_vswitch.default.id}"
+}
+`, resourceLaunchTemplateConfigDependence(name))
+}
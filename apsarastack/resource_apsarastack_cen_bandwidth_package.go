@@ -0,0 +1,270 @@
+package apsarastack
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cbn"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceApsaraStackCenBandwidthPackage() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackCenBandwidthPackageCreate,
+		Read:   resourceApsaraStackCenBandwidthPackageRead,
+		Update: resourceApsaraStackCenBandwidthPackageUpdate,
+		Delete: resourceApsaraStackCenBandwidthPackageDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(6 * time.Minute),
+			Update: schema.DefaultTimeout(6 * time.Minute),
+			Delete: schema.DefaultTimeout(6 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(2, 128),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(2, 256),
+			},
+			"bandwidth": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(2),
+			},
+			"geographic_region_a_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "China",
+			},
+			"geographic_region_b_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "China",
+			},
+			"charge_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      string(PostPaid),
+				ValidateFunc: validation.StringInSlice([]string{string(PrePaid), string(PostPaid)}, false),
+			},
+			"period": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      1,
+				ValidateFunc: validation.IntInSlice([]int{1, 2, 3, 6, 12}),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Get("charge_type").(string) == string(PostPaid)
+				},
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expired_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceApsaraStackCenBandwidthPackageCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	cenService := CenService{client}
+
+	request := cbn.CreateCreateCenBandwidthPackageRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "cbn", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.Name = d.Get("name").(string)
+	request.Description = d.Get("description").(string)
+	request.Bandwidth = requests.NewInteger(d.Get("bandwidth").(int))
+	request.GeographicRegionAId = d.Get("geographic_region_a_id").(string)
+	request.GeographicRegionBId = d.Get("geographic_region_b_id").(string)
+	request.BandwidthPackageChargeType = d.Get("charge_type").(string)
+	if request.BandwidthPackageChargeType == string(PrePaid) {
+		request.Period = requests.NewInteger(d.Get("period").(int))
+		request.PricingCycle = "Month"
+		request.AutoPay = requests.NewBoolean(true)
+	}
+
+	wait := incrementalWait(1*time.Second, 1*time.Second)
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		request.ClientToken = buildClientToken(request.GetActionName())
+		raw, err := client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+			return cbnClient.CreateCenBandwidthPackage(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"Operation.Blocking", Throttling}) {
+				wait()
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*cbn.CreateCenBandwidthPackageResponse)
+		d.SetId(response.CenBandwidthPackageId)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "apsarastack_cen_bandwidth_package", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{}, []string{string(Idle)}, d.Timeout(schema.TimeoutCreate), 3*time.Second, cenService.CenBandwidthPackageStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceApsaraStackCenBandwidthPackageRead(d, meta)
+}
+
+func resourceApsaraStackCenBandwidthPackageRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	cenService := CenService{client}
+	object, err := cenService.DescribeCenBandwidthPackage(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("name", object.Name)
+	d.Set("description", object.Description)
+	d.Set("bandwidth", int(object.Bandwidth))
+	d.Set("geographic_region_a_id", object.GeographicRegionAId)
+	d.Set("geographic_region_b_id", object.GeographicRegionBId)
+	d.Set("charge_type", object.BandwidthPackageChargeType)
+	d.Set("status", object.Status)
+	d.Set("expired_time", object.ExpiredTime)
+	return nil
+}
+
+func resourceApsaraStackCenBandwidthPackageUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	d.Partial(true)
+	if d.HasChange("name") || d.HasChange("description") {
+		request := cbn.CreateModifyCenBandwidthPackageAttributeRequest()
+		if strings.ToLower(client.Config.Protocol) == "https" {
+			request.Scheme = "https"
+		} else {
+			request.Scheme = "http"
+		}
+		request.RegionId = client.RegionId
+		request.Headers = map[string]string{"RegionId": client.RegionId}
+		request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "cbn", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+		request.CenBandwidthPackageId = d.Id()
+		request.Name = d.Get("name").(string)
+		request.Description = d.Get("description").(string)
+
+		raw, err := client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+			return cbnClient.ModifyCenBandwidthPackageAttribute(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		d.SetPartial("name")
+		d.SetPartial("description")
+	}
+
+	if d.HasChange("bandwidth") {
+		request := cbn.CreateModifyCenBandwidthPackageSpecRequest()
+		if strings.ToLower(client.Config.Protocol) == "https" {
+			request.Scheme = "https"
+		} else {
+			request.Scheme = "http"
+		}
+		request.RegionId = client.RegionId
+		request.Headers = map[string]string{"RegionId": client.RegionId}
+		request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "cbn", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+		request.CenBandwidthPackageId = d.Id()
+		request.Bandwidth = requests.NewInteger(d.Get("bandwidth").(int))
+
+		err := resource.Retry(d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
+			raw, err := client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+				return cbnClient.ModifyCenBandwidthPackageSpec(request)
+			})
+			if err != nil {
+				if IsExpectedErrors(err, []string{"Operation.Blocking", Throttling}) {
+					return resource.RetryableError(err)
+				}
+				return resource.NonRetryableError(err)
+			}
+			addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+			return nil
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		d.SetPartial("bandwidth")
+	}
+
+	d.Partial(false)
+	return resourceApsaraStackCenBandwidthPackageRead(d, meta)
+}
+
+func resourceApsaraStackCenBandwidthPackageDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	cenService := CenService{client}
+
+	request := cbn.CreateDeleteCenBandwidthPackageRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "cbn", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.CenBandwidthPackageId = d.Id()
+
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		raw, err := client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+			return cbnClient.DeleteCenBandwidthPackage(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"ParameterBwpInstanceId"}) {
+				return nil
+			}
+			if IsExpectedErrors(err, []string{"Forbidden.Release", "InvalidStatus.Resource", "Operation.Blocking", Throttling}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{string(Idle), "Deleting"}, []string{}, d.Timeout(schema.TimeoutDelete), 3*time.Second, cenService.CenBandwidthPackageStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
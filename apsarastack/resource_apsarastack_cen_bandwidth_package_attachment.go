@@ -0,0 +1,142 @@
+package apsarastack
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cbn"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceApsaraStackCenBandwidthPackageAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackCenBandwidthPackageAttachmentCreate,
+		Read:   resourceApsaraStackCenBandwidthPackageAttachmentRead,
+		Delete: resourceApsaraStackCenBandwidthPackageAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(6 * time.Minute),
+			Delete: schema.DefaultTimeout(6 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"bandwidth_package_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceApsaraStackCenBandwidthPackageAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	cenService := CenService{client}
+	bandwidthPackageId := d.Get("bandwidth_package_id").(string)
+
+	request := cbn.CreateAssociateCenBandwidthPackageRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "cbn", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.CenId = d.Get("instance_id").(string)
+	request.CenBandwidthPackageId = bandwidthPackageId
+
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		raw, err := client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+			return cbnClient.AssociateCenBandwidthPackage(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"Operation.Blocking", "InvalidOperation.CenInstanceStatus", Throttling}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "apsarastack_cen_bandwidth_package_attachment", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	d.SetId(bandwidthPackageId)
+
+	stateConf := BuildStateConf([]string{string(Idle)}, []string{string(InUse)}, d.Timeout(schema.TimeoutCreate), 3*time.Second, cenService.CenBandwidthPackageStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceApsaraStackCenBandwidthPackageAttachmentRead(d, meta)
+}
+
+func resourceApsaraStackCenBandwidthPackageAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	cenService := CenService{client}
+	object, err := cenService.DescribeCenBandwidthPackageAttachment(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("instance_id", object.CenIds.CenId[0])
+	d.Set("bandwidth_package_id", object.CenBandwidthPackageId)
+	return nil
+}
+
+func resourceApsaraStackCenBandwidthPackageAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	cenService := CenService{client}
+
+	request := cbn.CreateUnassociateCenBandwidthPackageRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "cbn", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.CenId = d.Get("instance_id").(string)
+	request.CenBandwidthPackageId = d.Id()
+
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		raw, err := client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+			return cbnClient.UnassociateCenBandwidthPackage(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"ParameterBwpInstanceId", "ParameterCenInstanceId"}) {
+				return nil
+			}
+			if IsExpectedErrors(err, []string{"Operation.Blocking", "InvalidOperation.CenInstanceStatus", "Forbidden.Relation", Throttling}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{string(InUse)}, []string{string(Idle)}, d.Timeout(schema.TimeoutDelete), 3*time.Second, cenService.CenBandwidthPackageStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cbn"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackCenBandwidthPackage_basic(t *testing.T) {
+	var v cbn.CenBandwidthPackage
+	rand := acctest.RandIntRange(1000, 9999)
+	resourceId := "apsarastack_cen_bandwidth_package.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"name":                   fmt.Sprintf("tf-testAccCenBandwidthPackage%d", rand),
+		"bandwidth":              "5",
+		"geographic_region_a_id": "China",
+		"geographic_region_b_id": "China",
+		"charge_type":            "PostPaid",
+		"status":                 "Idle",
+	})
+	serviceFunc := func() interface{} {
+		return &CenService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		// module name
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCenBandwidthPackageConfig(fmt.Sprintf("tf-testAccCenBandwidthPackage%d", rand), 5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:            resourceId,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"period"},
+			},
+			{
+				Config: testAccCenBandwidthPackageConfig(fmt.Sprintf("tf-testAccCenBandwidthPackage%d_change", rand), 5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"name": fmt.Sprintf("tf-testAccCenBandwidthPackage%d_change", rand),
+					}),
+				),
+			},
+			{
+				Config: testAccCenBandwidthPackageConfig(fmt.Sprintf("tf-testAccCenBandwidthPackage%d_change", rand), 10),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"bandwidth": "10",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccApsaraStackCenBandwidthPackageAttachment_basic(t *testing.T) {
+	var v cbn.CenBandwidthPackage
+	rand := acctest.RandIntRange(1000, 9999)
+	resourceId := "apsarastack_cen_bandwidth_package_attachment.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"instance_id":          CHECKSET,
+		"bandwidth_package_id": CHECKSET,
+	})
+	serviceFunc := func() interface{} {
+		return &CenService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		// module name
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCenBandwidthPackageAttachmentConfig(rand),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCenBandwidthPackageConfig(name string, bandwidth int) string {
+	return fmt.Sprintf(`
+resource "apsarastack_cen_bandwidth_package" "default" {
+  name = "%s"
+  bandwidth = %d
+}
+`, name, bandwidth)
+}
+
+func testAccCenBandwidthPackageAttachmentConfig(rand int) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "tf-testAccCenBandwidthPackageAttachment%d"
+}
+
+resource "apsarastack_cen_instance" "default" {
+  name = "${var.name}"
+}
+
+resource "apsarastack_cen_bandwidth_package" "default" {
+  name = "${var.name}"
+  bandwidth = 5
+}
+
+resource "apsarastack_cen_bandwidth_package_attachment" "default" {
+  instance_id = "${apsarastack_cen_instance.default.id}"
+  bandwidth_package_id = "${apsarastack_cen_bandwidth_package.default.id}"
+}
+`, rand)
+}
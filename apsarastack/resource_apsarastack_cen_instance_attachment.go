@@ -0,0 +1,197 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cbn"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceApsaraStackCenInstanceAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackCenInstanceAttachmentCreate,
+		Read:   resourceApsaraStackCenInstanceAttachmentRead,
+		Delete: resourceApsaraStackCenInstanceAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"child_instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"child_instance_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{ChildInstanceTypeVpc, ChildInstanceTypeVbr, ChildInstanceTypeCcn}, false),
+			},
+			"child_instance_region_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"child_instance_owner_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceApsaraStackCenInstanceAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	cenService := CenService{client}
+	cenId := d.Get("instance_id").(string)
+	childInstanceId := d.Get("child_instance_id").(string)
+
+	childInstanceType := d.Get("child_instance_type").(string)
+	if childInstanceType == "" {
+		instanceType, err := GetCenChildInstanceType(childInstanceId)
+		if err != nil {
+			return WrapError(err)
+		}
+		childInstanceType = instanceType
+	}
+	childInstanceRegionId := client.RegionId
+	if v, ok := d.GetOk("child_instance_region_id"); ok {
+		childInstanceRegionId = v.(string)
+	}
+
+	request := cbn.CreateAttachCenChildInstanceRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "cbn", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.CenId = cenId
+	request.ChildInstanceId = childInstanceId
+	request.ChildInstanceType = childInstanceType
+	request.ChildInstanceRegionId = childInstanceRegionId
+	if v, ok := d.GetOk("child_instance_owner_id"); ok {
+		request.ChildInstanceOwnerId = requests.NewInteger(v.(int))
+	}
+
+	// A CEN only accepts one attach or detach operation at a time, so concurrent attachments have to queue up.
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		raw, err := client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+			return cbnClient.AttachCenChildInstance(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"Operation.Blocking", "InvalidOperation.CenInstanceStatus", "InvalidOperation.ChildInstanceStatus", Throttling}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "apsarastack_cen_instance_attachment", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	d.SetId(fmt.Sprintf("%s%s%s", cenId, COLON_SEPARATED, childInstanceId))
+
+	stateConf := BuildStateConf([]string{"Attaching"}, []string{"Attached"}, d.Timeout(schema.TimeoutCreate), 3*time.Second, cenService.CenInstanceAttachmentStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceApsaraStackCenInstanceAttachmentRead(d, meta)
+}
+
+func resourceApsaraStackCenInstanceAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	cenService := CenService{client}
+	object, err := cenService.DescribeCenInstanceAttachment(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("instance_id", object.CenId)
+	d.Set("child_instance_id", object.ChildInstanceId)
+	d.Set("child_instance_type", object.ChildInstanceType)
+	d.Set("child_instance_region_id", object.ChildInstanceRegionId)
+	d.Set("child_instance_owner_id", int(object.ChildInstanceOwnerId))
+	d.Set("status", object.Status)
+	return nil
+}
+
+func resourceApsaraStackCenInstanceAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	cenService := CenService{client}
+
+	request := cbn.CreateDetachCenChildInstanceRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "cbn", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.CenId = d.Get("instance_id").(string)
+	request.ChildInstanceId = d.Get("child_instance_id").(string)
+	request.ChildInstanceType = d.Get("child_instance_type").(string)
+	request.ChildInstanceRegionId = d.Get("child_instance_region_id").(string)
+	if v, ok := d.GetOk("child_instance_owner_id"); ok {
+		request.ChildInstanceOwnerId = requests.NewInteger(v.(int))
+	}
+
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		raw, err := client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+			return cbnClient.DetachCenChildInstance(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"ParameterInstanceId", "ParameterCenInstanceId"}) {
+				return nil
+			}
+			if IsExpectedErrors(err, []string{"Operation.Blocking", "InvalidOperation.CenInstanceStatus", "InvalidOperation.ChildInstanceStatus", Throttling}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{"Attached", "Detaching"}, []string{}, d.Timeout(schema.TimeoutDelete), 3*time.Second, cenService.CenInstanceAttachmentStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
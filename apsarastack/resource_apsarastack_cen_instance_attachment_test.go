@@ -0,0 +1,76 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cbn"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackCenInstanceAttachment_basic(t *testing.T) {
+	var v cbn.ChildInstance
+	rand := acctest.RandIntRange(1000, 9999)
+	resourceId := "apsarastack_cen_instance_attachment.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"instance_id":              CHECKSET,
+		"child_instance_id":        CHECKSET,
+		"child_instance_type":      ChildInstanceTypeVpc,
+		"child_instance_region_id": CHECKSET,
+		"child_instance_owner_id":  CHECKSET,
+		"status":                   "Attached",
+	})
+	serviceFunc := func() interface{} {
+		return &CenService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		// module name
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCenInstanceAttachmentConfig(rand),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCenInstanceAttachmentConfig(rand int) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "tf-testAccCenInstanceAttachment%d"
+}
+
+resource "apsarastack_cen_instance" "default" {
+  name = "${var.name}"
+}
+
+resource "apsarastack_vpc" "default" {
+  name = "${var.name}"
+  cidr_block = "192.168.0.0/16"
+}
+
+resource "apsarastack_cen_instance_attachment" "default" {
+  instance_id = "${apsarastack_cen_instance.default.id}"
+  child_instance_id = "${apsarastack_vpc.default.id}"
+}
+`, rand)
+}
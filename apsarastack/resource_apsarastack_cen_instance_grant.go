@@ -0,0 +1,146 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceApsaraStackCenInstanceGrant() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackCenInstanceGrantCreate,
+		Read:   resourceApsaraStackCenInstanceGrantRead,
+		Delete: resourceApsaraStackCenInstanceGrantDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cen_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"child_instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"cen_owner_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceApsaraStackCenInstanceGrantCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	cenId := d.Get("cen_id").(string)
+	instanceId := d.Get("child_instance_id").(string)
+	cenOwnerId := d.Get("cen_owner_id").(string)
+
+	instanceType, err := GetCenChildInstanceType(instanceId)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	request := vpc.CreateGrantInstanceToCenRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.CenId = cenId
+	request.InstanceId = instanceId
+	request.InstanceType = instanceType
+	request.CenOwnerId = requests.Integer(cenOwnerId)
+	request.ClientToken = buildClientToken(request.GetActionName())
+
+	raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.GrantInstanceToCen(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "apsarastack_cen_instance_grant", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	d.SetId(fmt.Sprintf("%s%s%s%s%s", cenId, COLON_SEPARATED, instanceId, COLON_SEPARATED, cenOwnerId))
+
+	if err := vpcService.WaitForCenInstanceGrant(d.Id(), Active, DefaultTimeout); err != nil {
+		return WrapError(err)
+	}
+
+	return resourceApsaraStackCenInstanceGrantRead(d, meta)
+}
+
+func resourceApsaraStackCenInstanceGrantRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	parts, err := ParseResourceId(d.Id(), 3)
+	if err != nil {
+		return WrapError(err)
+	}
+	object, err := vpcService.DescribeCenInstanceGrant(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("cen_id", object.CenInstanceId)
+	d.Set("child_instance_id", parts[1])
+	d.Set("cen_owner_id", fmt.Sprint(object.CenOwnerId))
+	return nil
+}
+
+func resourceApsaraStackCenInstanceGrantDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	parts, err := ParseResourceId(d.Id(), 3)
+	if err != nil {
+		return WrapError(err)
+	}
+	instanceType, err := GetCenChildInstanceType(parts[1])
+	if err != nil {
+		return WrapError(err)
+	}
+
+	request := vpc.CreateRevokeInstanceFromCenRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.CenId = parts[0]
+	request.InstanceId = parts[1]
+	request.InstanceType = instanceType
+	request.CenOwnerId = requests.Integer(parts[2])
+	request.ClientToken = buildClientToken(request.GetActionName())
+
+	raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.RevokeInstanceFromCen(request)
+	})
+	if err != nil {
+		if IsExpectedErrors(err, []string{"InvalidInstanceId.NotFound"}) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+
+	return WrapError(vpcService.WaitForCenInstanceGrant(d.Id(), Deleted, DefaultTimeout))
+}
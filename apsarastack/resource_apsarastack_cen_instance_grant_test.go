@@ -0,0 +1,68 @@
+package apsarastack
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+// The VPC is owned by the second account and granted to the CEN owned by the default account,
+// so the grant can only be read back through the second provider.
+func TestAccApsaraStackCenInstanceGrant_basic(t *testing.T) {
+	rand := acctest.RandIntRange(1000, 9999)
+	resourceId := "apsarastack_cen_instance_grant.default"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWithMultipleAccount(t)
+		},
+		// module name
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCenInstanceGrantConfig(rand),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceId, "cen_id"),
+					resource.TestCheckResourceAttrSet(resourceId, "child_instance_id"),
+					resource.TestCheckResourceAttr(resourceId, "cen_owner_id", os.Getenv("APSARASTACK_ACCOUNT_ID")),
+				),
+			},
+		},
+	})
+}
+
+func testAccCenInstanceGrantConfig(rand int) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "tf-testAccCenInstanceGrant%d"
+}
+
+provider "apsarastack" {
+  alias = "account2"
+  access_key = "%s"
+  secret_key = "%s"
+}
+
+resource "apsarastack_cen_instance" "default" {
+  name = "${var.name}"
+}
+
+resource "apsarastack_vpc" "default" {
+  provider = "apsarastack.account2"
+  name = "${var.name}"
+  cidr_block = "192.168.0.0/16"
+}
+
+resource "apsarastack_cen_instance_grant" "default" {
+  provider = "apsarastack.account2"
+  cen_id = "${apsarastack_cen_instance.default.id}"
+  child_instance_id = "${apsarastack_vpc.default.id}"
+  cen_owner_id = "%s"
+}
+`, rand, os.Getenv("APSARASTACK_ACCESS_KEY_2"), os.Getenv("APSARASTACK_SECRET_KEY_2"), os.Getenv("APSARASTACK_ACCOUNT_ID"))
+}
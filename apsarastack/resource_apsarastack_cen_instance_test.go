@@ -0,0 +1,177 @@
+package apsarastack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cbn"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("apsarastack_cen_instance", &resource.Sweeper{
+		Name: "apsarastack_cen_instance",
+		F:    testSweepCenInstances,
+		// When implemented, these should be removed firstly
+		Dependencies: []string{
+			"apsarastack_cen_bandwidth_package",
+		},
+	})
+}
+
+func testSweepCenInstances(region string) error {
+	rawClient, err := sharedClientForRegion(region)
+	if err != nil {
+		return fmt.Errorf("error getting apsarastack client: %s", err)
+	}
+	client := rawClient.(*connectivity.ApsaraStackClient)
+
+	prefixes := []string{
+		"tf-testAcc",
+		"tf_testAcc",
+	}
+
+	var cens []cbn.Cen
+	request := cbn.CreateDescribeCensRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "cbn", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+	for {
+		raw, err := client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+			return cbnClient.DescribeCens(request)
+		})
+		if err != nil {
+			return fmt.Errorf("Error retrieving CEN Instances: %s", err)
+		}
+		response, _ := raw.(*cbn.DescribeCensResponse)
+		if len(response.Cens.Cen) < 1 {
+			break
+		}
+		cens = append(cens, response.Cens.Cen...)
+
+		if len(response.Cens.Cen) < PageSizeLarge {
+			break
+		}
+		page, err := getNextpageNumber(request.PageNumber)
+		if err != nil {
+			return err
+		}
+		request.PageNumber = page
+	}
+
+	for _, cen := range cens {
+		name := cen.Name
+		id := cen.CenId
+		skip := true
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(strings.ToLower(name), strings.ToLower(prefix)) {
+				skip = false
+				break
+			}
+		}
+		if skip {
+			log.Printf("[INFO] Skipping CEN Instance: %s (%s)", name, id)
+			continue
+		}
+		log.Printf("[INFO] Deleting CEN Instance: %s (%s)", name, id)
+		request := cbn.CreateDeleteCenRequest()
+		if strings.ToLower(client.Config.Protocol) == "https" {
+			request.Scheme = "https"
+		} else {
+			request.Scheme = "http"
+		}
+		request.RegionId = client.RegionId
+		request.Headers = map[string]string{"RegionId": client.RegionId}
+		request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "cbn", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+		request.CenId = id
+		_, err := client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+			return cbnClient.DeleteCen(request)
+		})
+		if err != nil {
+			log.Printf("[ERROR] Failed to delete CEN Instance (%s (%s)): %s", name, id, err)
+		}
+	}
+	return nil
+}
+
+func TestAccApsaraStackCenInstance_basic(t *testing.T) {
+	var v cbn.Cen
+	rand := acctest.RandIntRange(1000, 9999)
+	resourceId := "apsarastack_cen_instance.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"name":             fmt.Sprintf("tf-testAccCenInstance%d", rand),
+		"description":      "",
+		"protection_level": "REDUCED",
+		"status":           "Active",
+	})
+	serviceFunc := func() interface{} {
+		return &CenService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		// module name
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCenInstanceConfig(fmt.Sprintf("tf-testAccCenInstance%d", rand), ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccCenInstanceConfig(fmt.Sprintf("tf-testAccCenInstance%d_change", rand), ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"name": fmt.Sprintf("tf-testAccCenInstance%d_change", rand),
+					}),
+				),
+			},
+			{
+				Config: testAccCenInstanceConfig(fmt.Sprintf("tf-testAccCenInstance%d_change", rand), fmt.Sprintf("tf-testAccCenInstance%d_description", rand)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"description": fmt.Sprintf("tf-testAccCenInstance%d_description", rand),
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCenInstanceConfig(name, description string) string {
+	descriptionConfig := ""
+	if description != "" {
+		descriptionConfig = fmt.Sprintf(`description = "%s"`, description)
+	}
+	return fmt.Sprintf(`
+resource "apsarastack_cen_instance" "default" {
+  name = "%s"
+  %s
+}
+`, name, descriptionConfig)
+}
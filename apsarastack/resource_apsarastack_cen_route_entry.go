@@ -0,0 +1,188 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cbn"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceApsaraStackCenRouteEntry() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackCenRouteEntryCreate,
+		Read:   resourceApsaraStackCenRouteEntryRead,
+		Delete: resourceApsaraStackCenRouteEntryDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(6 * time.Minute),
+			Delete: schema.DefaultTimeout(6 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"route_table_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"cidr_block": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsCIDR,
+			},
+		},
+	}
+}
+
+func resourceApsaraStackCenRouteEntryCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	cenService := CenService{client}
+	vpcService := VpcService{client}
+	cenId := d.Get("instance_id").(string)
+	routeTableId := d.Get("route_table_id").(string)
+	cidrBlock := d.Get("cidr_block").(string)
+
+	routeTable, err := vpcService.DescribeRouteTable(routeTableId)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	request := cbn.CreatePublishRouteEntriesRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "cbn", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.CenId = cenId
+	request.ChildInstanceId = routeTable.VpcId
+	request.ChildInstanceType = ChildInstanceTypeVpc
+	request.ChildInstanceRegionId = client.RegionId
+	request.ChildInstanceRouteTableId = routeTableId
+	request.DestinationCidrBlock = cidrBlock
+
+	err = resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		raw, err := client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+			return cbnClient.PublishRouteEntries(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"Operation.Blocking", "InvalidOperation.CenInstanceStatus", "InvalidStatus.RouteEntry", Throttling}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "apsarastack_cen_route_entry", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	d.SetId(fmt.Sprintf("%s%s%s%s%s", cenId, COLON_SEPARATED, routeTableId, COLON_SEPARATED, cidrBlock))
+
+	stateConf := BuildStateConf([]string{"Publishing"}, []string{"Published"}, d.Timeout(schema.TimeoutCreate), 3*time.Second, cenService.CenRouteEntryStateRefreshFunc(d.Id(), []string{"Conflicted"}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceApsaraStackCenRouteEntryRead(d, meta)
+}
+
+func resourceApsaraStackCenRouteEntryRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	cenService := CenService{client}
+	parts, err := ParseResourceId(d.Id(), 3)
+	if err != nil {
+		return WrapError(err)
+	}
+	object, err := cenService.DescribeCenRouteEntry(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+	if object.PublishStatus != "Published" {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("instance_id", parts[0])
+	d.Set("route_table_id", object.ChildInstanceRouteTableId)
+	d.Set("cidr_block", object.DestinationCidrBlock)
+	return nil
+}
+
+func resourceApsaraStackCenRouteEntryDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	cenService := CenService{client}
+	vpcService := VpcService{client}
+	parts, err := ParseResourceId(d.Id(), 3)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	routeTable, err := vpcService.DescribeRouteTable(parts[1])
+	if err != nil {
+		if NotFoundError(err) {
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	request := cbn.CreateWithdrawPublishedRouteEntriesRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "cbn", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.CenId = parts[0]
+	request.ChildInstanceId = routeTable.VpcId
+	request.ChildInstanceType = ChildInstanceTypeVpc
+	request.ChildInstanceRegionId = client.RegionId
+	request.ChildInstanceRouteTableId = parts[1]
+	request.DestinationCidrBlock = parts[2]
+
+	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		raw, err := client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+			return cbnClient.WithdrawPublishedRouteEntries(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"ParameterCenInstanceId", "InvalidRouteEntry.NotFound"}) {
+				return nil
+			}
+			if IsExpectedErrors(err, []string{"Operation.Blocking", "InvalidOperation.CenInstanceStatus", "InvalidStatus.RouteEntry", Throttling}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{"Published", "Withdrawing"}, []string{"NonPublished"}, d.Timeout(schema.TimeoutDelete), 3*time.Second, cenService.CenRouteEntryStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cbn"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccApsaraStackCenRouteEntry_basic(t *testing.T) {
+	var v cbn.PublishedRouteEntry
+	rand := acctest.RandIntRange(1000, 9999)
+	resourceId := "apsarastack_cen_route_entry.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"instance_id":    CHECKSET,
+		"route_table_id": CHECKSET,
+		"cidr_block":     "11.0.0.0/16",
+	})
+	serviceFunc := func() interface{} {
+		return &CenService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		// module name
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckCenRouteEntryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCenRouteEntryConfig(rand),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Withdrawn entries are still returned as NonPublished while the VPC route entry exists.
+func testAccCheckCenRouteEntryDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*connectivity.ApsaraStackClient)
+	cenService := CenService{client}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "apsarastack_cen_route_entry" {
+			continue
+		}
+		entry, err := cenService.DescribeCenRouteEntry(rs.Primary.ID)
+		if err != nil {
+			if NotFoundError(err) {
+				continue
+			}
+			return WrapError(err)
+		}
+		if entry.PublishStatus == "Published" {
+			return WrapError(Error("CEN route entry %s still exists", rs.Primary.ID))
+		}
+	}
+	return nil
+}
+
+func testAccCenRouteEntryConfig(rand int) string {
+	return fmt.Sprintf(`
+data "apsarastack_zones" "default" {
+  available_resource_creation = "VSwitch"
+}
+
+data "apsarastack_instance_types" "default" {
+  availability_zone = "${data.apsarastack_zones.default.zones.0.id}"
+}
+
+data "apsarastack_images" "default" {
+  name_regex = "^ubuntu_18\\w{1,5}[64]{1}.*"
+  most_recent = true
+  owners = "system"
+}
+
+variable "name" {
+  default = "tf-testAccCenRouteEntry%d"
+}
+
+resource "apsarastack_cen_instance" "default" {
+  name = "${var.name}"
+}
+
+resource "apsarastack_vpc" "default" {
+  name = "${var.name}"
+  cidr_block = "172.16.0.0/12"
+}
+
+resource "apsarastack_vswitch" "default" {
+  vpc_id = "${apsarastack_vpc.default.id}"
+  cidr_block = "172.16.0.0/21"
+  availability_zone = "${data.apsarastack_zones.default.zones.0.id}"
+  name = "${var.name}"
+}
+
+resource "apsarastack_security_group" "default" {
+  name = "${var.name}"
+  vpc_id = "${apsarastack_vpc.default.id}"
+}
+
+resource "apsarastack_instance" "default" {
+  security_groups = ["${apsarastack_security_group.default.id}"]
+  vswitch_id = "${apsarastack_vswitch.default.id}"
+  instance_type = "${data.apsarastack_instance_types.default.instance_types.0.id}"
+  system_disk_category = "cloud_efficiency"
+  image_id = "${data.apsarastack_images.default.images.0.id}"
+  instance_name = "${var.name}"
+}
+
+resource "apsarastack_route_entry" "default" {
+  route_table_id = "${apsarastack_vpc.default.route_table_id}"
+  destination_cidrblock = "11.0.0.0/16"
+  nexthop_type = "Instance"
+  nexthop_id = "${apsarastack_instance.default.id}"
+}
+
+resource "apsarastack_cen_instance_attachment" "default" {
+  instance_id = "${apsarastack_cen_instance.default.id}"
+  child_instance_id = "${apsarastack_vpc.default.id}"
+}
+
+resource "apsarastack_cen_route_entry" "default" {
+  instance_id = "${apsarastack_cen_instance_attachment.default.instance_id}"
+  route_table_id = "${apsarastack_vpc.default.route_table_id}"
+  cidr_block = "${apsarastack_route_entry.default.destination_cidrblock}"
+}
+`, rand)
+}
@@ -53,12 +53,12 @@ func resourceApsaraStackCommonBandwidthPackage() *schema.Resource {
 				Default:      100,
 				ValidateFunc: validation.IntBetween(10, 100),
 			},
-			//"resource_group_id": {
-			//	Type:     schema.TypeString,
-			//	Optional: true,
-			//	ForceNew: true,
-			//	Computed: true,
-			//},
+			"resource_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -83,6 +83,9 @@ func resourceApsaraStackCommonBandwidthPackageCreate(d *schema.ResourceData, met
 	request.Description = d.Get("description").(string)
 	request.InternetChargeType = d.Get("internet_charge_type").(string)
 	request.Ratio = requests.NewInteger(d.Get("ratio").(int))
+	if v, ok := d.GetOk("resource_group_id"); ok {
+		request.ResourceGroupId = v.(string)
+	}
 
 	wait := incrementalWait(1*time.Second, 1*time.Second)
 	err := resource.Retry(10*time.Minute, func() *resource.RetryError {
@@ -110,6 +113,9 @@ func resourceApsaraStackCommonBandwidthPackageCreate(d *schema.ResourceData, met
 	if err = vpcService.WaitForCommonBandwidthPackage(d.Id(), Available, DefaultTimeout); err != nil {
 		return WrapError(err)
 	}
+	if err := vpcService.setInstanceTags(d, TagResourceCbwp); err != nil {
+		return WrapError(err)
+	}
 
 	return resourceApsaraStackCommonBandwidthPackageRead(d, meta)
 }
@@ -135,13 +141,26 @@ func resourceApsaraStackCommonBandwidthPackageRead(d *schema.ResourceData, meta
 	d.Set("description", object.Description)
 	d.Set("internet_charge_type", object.InternetChargeType)
 	d.Set("ratio", object.Ratio)
+	d.Set("resource_group_id", object.ResourceGroupId)
+	tags, err := vpcService.DescribeTags(d.Id(), nil, TagResourceCbwp)
+	if err != nil {
+		return WrapError(err)
+	}
+	d.Set("tags", vpcService.tagsToMap(tags))
 	return nil
 }
 
 func resourceApsaraStackCommonBandwidthPackageUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
 
 	d.Partial(true)
+	if err := vpcService.setInstanceTags(d, TagResourceCbwp); err != nil {
+		return WrapError(err)
+	}
+	if err := vpcService.setResourceGroup(d, "bandwidthpackage"); err != nil {
+		return WrapError(err)
+	}
 	update := false
 	request := vpc.CreateModifyCommonBandwidthPackageAttributeRequest()
 	if strings.ToLower(client.Config.Protocol) == "https" {
@@ -63,11 +63,13 @@ func resourceApsaraStackDisk() *schema.Resource {
 			"kms_key_id": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
 
 			"encrypted": {
 				Type:          schema.TypeBool,
 				Optional:      true,
+				Computed:      true,
 				ForceNew:      true,
 				ConflictsWith: []string{"snapshot_id"},
 			},
@@ -155,10 +157,23 @@ func resourceApsaraStackDiskCreate(d *schema.ResourceData, meta interface{}) err
 			if j, ok4 := d.GetOk("kms_key_id"); ok4 {
 				request.KMSKeyId = j.(string)
 			}
+			if request.KMSKeyId == "" {
+				request.KMSKeyId = client.Config.DefaultDiskKMSKeyId
+			}
 			if request.KMSKeyId == "" {
 				return WrapError(errors.New("KmsKeyId can not be empty if encrypted is set to \"true\""))
 			}
 		}
+	} else if client.Config.DefaultDiskKMSKeyId != "" && request.SnapshotId == "" {
+		// Disks created from a snapshot inherit the encryption of the snapshot.
+		if v, ok := d.GetOkExists("encrypted"); ok && !v.(bool) {
+			return WrapError(errors.New("encrypted can not be set to \"false\" when the provider default_disk_encryption is set"))
+		}
+		request.Encrypted = requests.NewBoolean(true)
+		request.KMSKeyId = client.Config.DefaultDiskKMSKeyId
+		if v, ok := d.GetOk("kms_key_id"); ok {
+			request.KMSKeyId = v.(string)
+		}
 	}
 	if v, ok := d.GetOk("tags"); ok && len(v.(map[string]interface{})) > 0 {
 		tags := make([]ecs.CreateDiskTag, len(v.(map[string]interface{})))
@@ -3,6 +3,7 @@ package apsarastack
 import (
 	"fmt"
 	"log"
+	"os"
 	"strings"
 	"testing"
 
@@ -269,6 +270,63 @@ func TestAccApsaraStackDisk_multi(t *testing.T) {
 
 }
 
+func TestAccApsaraStackDisk_defaultEncryption(t *testing.T) {
+	var v ecs.Disk
+	resourceId := "apsarastack_disk.default"
+	serverFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serverFunc)
+	ra := resourceAttrInit(resourceId, testAccCheckResourceDiskBasicMap)
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	kmsKeyId := os.Getenv("APSARASTACK_DEFAULT_DISK_KMS_KEY_ID")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckDefaultDiskEncryption(t)
+		},
+
+		// module name
+		IDRefreshName: "apsarastack_disk.default",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDiskDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDiskConfig_defaultEncryption(kmsKeyId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"encrypted":  "true",
+						"kms_key_id": kmsKeyId,
+					}),
+				),
+			},
+		},
+	})
+
+}
+
+func testAccDiskConfig_defaultEncryption(kmsKeyId string) string {
+	return fmt.Sprintf(`
+provider "apsarastack" {
+	assume_role {}
+	default_disk_encryption {
+		kms_key_id = "%s"
+	}
+}
+
+data "apsarastack_zones" "default" {
+	available_resource_creation= "VSwitch"
+}
+
+resource "apsarastack_disk" "default" {
+	availability_zone = "${data.apsarastack_zones.default.zones.0.id}"
+  	size = "50"
+}
+`, kmsKeyId)
+}
+
 func testAccDiskConfig_basic() string {
 	return fmt.Sprintf(`
 data "apsarastack_zones" "default" {
@@ -0,0 +1,219 @@
+package apsarastack
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceApsaraStackEcsCommand() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackEcsCommandCreate,
+		Read:   resourceApsaraStackEcsCommandRead,
+		Update: resourceApsaraStackEcsCommandUpdate,
+		Delete: resourceApsaraStackEcsCommandDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 128),
+			},
+			"command_content": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: userDataDiffSuppressFunc,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"RunShellScript", "RunBatScript", "RunPowerShellScript"}, false),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 512),
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntBetween(10, 86400),
+			},
+			"working_dir": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"enable_parameter": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceApsaraStackEcsCommandCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	request := ecs.CreateCreateCommandRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.Name = d.Get("name").(string)
+	request.Type = d.Get("type").(string)
+	request.CommandContent = encodeCommandContent(d.Get("command_content").(string))
+	request.Timeout = requests.NewInteger(d.Get("timeout").(int))
+	request.EnableParameter = requests.NewBoolean(d.Get("enable_parameter").(bool))
+	if v, ok := d.GetOk("description"); ok {
+		request.Description = v.(string)
+	}
+	if v, ok := d.GetOk("working_dir"); ok {
+		request.WorkingDir = v.(string)
+	}
+
+	wait := incrementalWait(3*time.Second, 3*time.Second)
+	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.CreateCommand(request)
+		})
+		if err != nil {
+			if NeedRetry(err) {
+				wait()
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*ecs.CreateCommandResponse)
+		d.SetId(response.CommandId)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "apsarastack_ecs_command", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	return resourceApsaraStackEcsCommandRead(d, meta)
+}
+
+func resourceApsaraStackEcsCommandRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	object, err := ecsService.DescribeEcsCommand(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("name", object.Name)
+	d.Set("command_content", userDataHashSum(object.CommandContent))
+	d.Set("type", object.Type)
+	d.Set("description", object.Description)
+	d.Set("timeout", object.Timeout)
+	d.Set("working_dir", object.WorkingDir)
+	d.Set("enable_parameter", object.EnableParameter)
+	return nil
+}
+
+func resourceApsaraStackEcsCommandUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	update := false
+	request := ecs.CreateModifyCommandRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.CommandId = d.Id()
+
+	if d.HasChange("name") {
+		request.Name = d.Get("name").(string)
+		update = true
+	}
+	if d.HasChange("command_content") {
+		request.CommandContent = encodeCommandContent(d.Get("command_content").(string))
+		update = true
+	}
+	if d.HasChange("description") {
+		request.Description = d.Get("description").(string)
+		update = true
+	}
+	if d.HasChange("timeout") {
+		request.Timeout = requests.NewInteger(d.Get("timeout").(int))
+		update = true
+	}
+	if d.HasChange("working_dir") {
+		request.WorkingDir = d.Get("working_dir").(string)
+		update = true
+	}
+
+	if update {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifyCommand(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	}
+	return resourceApsaraStackEcsCommandRead(d, meta)
+}
+
+func resourceApsaraStackEcsCommandDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	request := ecs.CreateDeleteCommandRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.CommandId = d.Id()
+
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DeleteCommand(request)
+	})
+	if err != nil {
+		if IsExpectedErrors(err, []string{"InvalidCmdId.NotFound"}) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	return nil
+}
+
+// encodeCommandContent returns the command content base64 encoded, as Cloud Assistant expects it.
+func encodeCommandContent(content string) string {
+	if _, err := base64.StdEncoding.DecodeString(content); err == nil {
+		return content
+	}
+	return base64.StdEncoding.EncodeToString([]byte(content))
+}
@@ -0,0 +1,117 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackEcsCommand_basic(t *testing.T) {
+	var v ecs.Command
+	resourceId := "apsarastack_ecs_command.default"
+	ra := resourceAttrInit(resourceId, testAccEcsCommandCheckMap)
+	serviceFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	rand := acctest.RandIntRange(10000, 99999)
+	name := fmt.Sprintf("tf-testacc-ecscommand%d", rand)
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, testAccEcsCommandBasicDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"name":            "${var.name}",
+					"command_content": "ZWNobyBoZWxsbw==",
+					"type":            "RunShellScript",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"name":            name,
+						"command_content": "echo hello",
+						"type":            "RunShellScript",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"command_content": "echo world",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"command_content": "echo world",
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"description": name,
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"description": name,
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"timeout":     "120",
+					"working_dir": "/root",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"timeout":     "120",
+						"working_dir": "/root",
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"name":            "${var.name}_update",
+					"command_content": "ZWNobyBoZWxsbw==",
+					"description":     REMOVEKEY,
+					"timeout":         REMOVEKEY,
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"name":            name + "_update",
+						"command_content": "echo hello",
+						"description":     "",
+						"timeout":         "60",
+					}),
+				),
+			},
+		},
+	})
+}
+
+var testAccEcsCommandCheckMap = map[string]string{
+	"timeout":          "60",
+	"enable_parameter": "false",
+}
+
+func testAccEcsCommandBasicDependence(name string) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "%s"
+}
+`, name)
+}
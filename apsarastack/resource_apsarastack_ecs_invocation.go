@@ -0,0 +1,260 @@
+package apsarastack
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceApsaraStackEcsInvocation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackEcsInvocationCreate,
+		Read:   resourceApsaraStackEcsInvocationRead,
+		Delete: resourceApsaraStackEcsInvocationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"command_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"instance_ids": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				MaxItems: 50,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"parameters": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"username": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"invocation_results": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"exit_code": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"output": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"error_code": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"error_info": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceApsaraStackEcsInvocationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+
+	instanceIds := expandStringList(d.Get("instance_ids").([]interface{}))
+	// Cloud Assistant can only run commands on running instances.
+	for _, instanceId := range instanceIds {
+		if err := ecsService.WaitForEcsInstance(instanceId, Running, DefaultTimeout); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	request := ecs.CreateInvokeCommandRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.CommandId = d.Get("command_id").(string)
+	request.InstanceId = &instanceIds
+	if v, ok := d.GetOk("parameters"); ok && len(v.(map[string]interface{})) > 0 {
+		request.Parameters = v.(map[string]interface{})
+	}
+	if v, ok := d.GetOk("username"); ok {
+		request.Username = v.(string)
+	}
+
+	wait := incrementalWait(3*time.Second, 3*time.Second)
+	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.InvokeCommand(request)
+		})
+		if err != nil {
+			if NeedRetry(err) || IsExpectedErrors(err, []string{"InstanceNotRunning", "ClientNotRunning"}) {
+				wait()
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*ecs.InvokeCommandResponse)
+		d.SetId(response.InvokeId)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "apsarastack_ecs_invocation", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{"Pending", "Scheduled", "Running"}, []string{"Success", "Finished"}, d.Timeout(schema.TimeoutCreate), 5*time.Second,
+		ecsService.EcsInvocationStateRefreshFunc(d.Id(), []string{"Failed", "PartialFailed", "Stopped"}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		object, e := ecsService.DescribeEcsInvocation(d.Id())
+		if e == nil && (object.InvocationStatus == "Failed" || object.InvocationStatus == "PartialFailed") {
+			results, e := ecsService.DescribeEcsInvocationResults(d.Id())
+			if e != nil {
+				return WrapError(e)
+			}
+			return WrapErrorf(err, IdMsg+"\n%s", d.Id(), ecsInvocationFailures(results))
+		}
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceApsaraStackEcsInvocationRead(d, meta)
+}
+
+func resourceApsaraStackEcsInvocationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	object, err := ecsService.DescribeEcsInvocation(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+	d.Set("command_id", object.CommandId)
+	d.Set("status", object.InvocationStatus)
+	if object.Username != "" {
+		d.Set("username", object.Username)
+	}
+
+	results, err := ecsService.DescribeEcsInvocationResults(d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+	instanceIds := make([]string, 0, len(results))
+	invocationResults := make([]map[string]interface{}, 0, len(results))
+	for _, result := range results {
+		instanceIds = append(instanceIds, result.InstanceId)
+		invocationResults = append(invocationResults, map[string]interface{}{
+			"instance_id": result.InstanceId,
+			"status":      result.InvocationStatus,
+			"exit_code":   int(result.ExitCode),
+			"output":      decodeEcsInvocationOutput(result.Output),
+			"error_code":  result.ErrorCode,
+			"error_info":  result.ErrorInfo,
+		})
+	}
+	if _, ok := d.GetOk("instance_ids"); !ok {
+		d.Set("instance_ids", instanceIds)
+	}
+	if err := d.Set("invocation_results", invocationResults); err != nil {
+		return WrapError(err)
+	}
+	return nil
+}
+
+func resourceApsaraStackEcsInvocationDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	object, err := ecsService.DescribeEcsInvocation(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			return nil
+		}
+		return WrapError(err)
+	}
+	// The invocation record can not be deleted, only an unfinished invocation is stopped.
+	if object.InvocationStatus != "Pending" && object.InvocationStatus != "Scheduled" && object.InvocationStatus != "Running" {
+		return nil
+	}
+
+	request := ecs.CreateStopInvocationRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.InvokeId = d.Id()
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.StopInvocation(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	return nil
+}
+
+// decodeEcsInvocationOutput decodes the base64 encoded output of a command run, and keeps it as is if it is not encoded.
+func decodeEcsInvocationOutput(output string) string {
+	decoded, err := base64.StdEncoding.DecodeString(output)
+	if err != nil {
+		return output
+	}
+	return string(decoded)
+}
+
+// ecsInvocationFailures describes the instances on which a command run did not succeed, one per line.
+func ecsInvocationFailures(results []ecs.InvocationResult) string {
+	var failures []string
+	for _, result := range results {
+		if result.InvocationStatus == "Success" || result.InvocationStatus == "Finished" {
+			continue
+		}
+		failure := fmt.Sprintf("%s: status %s, exit code %d", result.InstanceId, result.InvocationStatus, result.ExitCode)
+		if result.ErrorInfo != "" {
+			failure += fmt.Sprintf(", error %s %s", result.ErrorCode, result.ErrorInfo)
+		}
+		failures = append(failures, failure+", output: "+decodeEcsInvocationOutput(result.Output))
+	}
+	return strings.Join(failures, "\n")
+}
@@ -0,0 +1,110 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackEcsInvocation_basic(t *testing.T) {
+	var v ecs.Invocation
+	resourceId := "apsarastack_ecs_invocation.default"
+	ra := resourceAttrInit(resourceId, testAccEcsInvocationCheckMap)
+	serviceFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	rand := acctest.RandIntRange(10000, 99999)
+	name := fmt.Sprintf("tf-testacc-ecsinvocation%d", rand)
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, testAccEcsInvocationBasicDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"command_id":   "${apsarastack_ecs_command.default.id}",
+					"instance_ids": []string{"${apsarastack_instance.default.id}"},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"command_id":                       CHECKSET,
+						"instance_ids.#":                   "1",
+						"status":                           "Finished",
+						"invocation_results.#":             "1",
+						"invocation_results.0.exit_code":   "0",
+						"invocation_results.0.output":      "hello\n",
+						"invocation_results.0.instance_id": CHECKSET,
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+var testAccEcsInvocationCheckMap = map[string]string{}
+
+func testAccEcsInvocationBasicDependence(name string) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "%s"
+}
+data "apsarastack_zones" "default" {
+  available_disk_category     = "cloud_efficiency"
+  available_resource_creation = "VSwitch"
+}
+data "apsarastack_images" "default" {
+  name_regex  = "^ubuntu_18.*64"
+  most_recent = true
+  owners      = "system"
+}
+data "apsarastack_instance_types" "default" {
+  availability_zone = data.apsarastack_zones.default.zones.0.id
+  cpu_core_count    = 1
+  memory_size       = 2
+}
+resource "apsarastack_vpc" "default" {
+  name       = var.name
+  cidr_block = "172.16.0.0/16"
+}
+resource "apsarastack_vswitch" "default" {
+  vpc_id            = apsarastack_vpc.default.id
+  cidr_block        = "172.16.0.0/24"
+  availability_zone = data.apsarastack_zones.default.zones.0.id
+  name              = var.name
+}
+resource "apsarastack_security_group" "default" {
+  name   = var.name
+  vpc_id = apsarastack_vpc.default.id
+}
+resource "apsarastack_instance" "default" {
+  image_id             = data.apsarastack_images.default.images.0.id
+  instance_type        = data.apsarastack_instance_types.default.instance_types.0.id
+  instance_name        = var.name
+  security_groups      = [apsarastack_security_group.default.id]
+  vswitch_id           = apsarastack_vswitch.default.id
+  system_disk_category = "cloud_efficiency"
+}
+resource "apsarastack_ecs_command" "default" {
+  name            = var.name
+  command_content = "echo hello"
+  type            = "RunShellScript"
+}
+`, name)
+}
@@ -0,0 +1,304 @@
+package apsarastack
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceApsaraStackEcsSnapshotGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackEcsSnapshotGroupCreate,
+		Read:   resourceApsaraStackEcsSnapshotGroupRead,
+		Update: resourceApsaraStackEcsSnapshotGroupUpdate,
+		Delete: resourceApsaraStackEcsSnapshotGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(DefaultTimeout * time.Second),
+		},
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"disk_ids": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"exclude_disk_ids"},
+			},
+			"exclude_disk_ids": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ForceNew:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"disk_ids"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(2, 128),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(2, 256),
+			},
+			"instant_access": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+			"instant_access_retention_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(1, 65535),
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"snapshots": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"snapshot_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"disk_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"progress": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceApsaraStackEcsSnapshotGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+
+	request := ecs.CreateCreateSnapshotGroupRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.InstanceId = d.Get("instance_id").(string)
+	if v, ok := d.GetOk("disk_ids"); ok {
+		diskIds := expandStringList(v.(*schema.Set).List())
+		request.DiskId = &diskIds
+	}
+	if v, ok := d.GetOk("exclude_disk_ids"); ok {
+		excludeDiskIds := expandStringList(v.(*schema.Set).List())
+		request.ExcludeDiskId = &excludeDiskIds
+	}
+	if v, ok := d.GetOk("name"); ok {
+		request.Name = v.(string)
+	}
+	if v, ok := d.GetOk("description"); ok {
+		request.Description = v.(string)
+	}
+	if v, ok := d.GetOk("instant_access"); ok {
+		request.InstantAccess = requests.NewBoolean(v.(bool))
+	}
+	if v, ok := d.GetOk("instant_access_retention_days"); ok {
+		request.InstantAccessRetentionDays = requests.NewInteger(v.(int))
+	}
+
+	var raw interface{}
+	var err error
+	err = resource.Retry(DefaultTimeout*time.Second, func() *resource.RetryError {
+		raw, err = client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.CreateSnapshotGroup(request)
+		})
+		if err != nil {
+			if NeedRetry(err) || IsExpectedErrors(err, SnapshotInvalidOperations) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "apsarastack_ecs_snapshot_group", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	response, _ := raw.(*ecs.CreateSnapshotGroupResponse)
+	d.SetId(response.SnapshotGroupId)
+
+	// The snapshots of a group are registered asynchronously, wait for them to be listed
+	// before waiting for each of them to be accomplished.
+	var group ecs.SnapshotGroup
+	err = resource.Retry(5*time.Minute, func() *resource.RetryError {
+		group, err = ecsService.DescribeEcsSnapshotGroup(d.Id())
+		if err != nil {
+			if NotFoundError(err) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		if len(group.Snapshots.Snapshot) == 0 {
+			return resource.RetryableError(WrapError(Error("waiting for the snapshots of snapshot group %s", d.Id())))
+		}
+		return nil
+	})
+	if err != nil {
+		return WrapError(err)
+	}
+
+	for _, snapshot := range group.Snapshots.Snapshot {
+		stateConf := BuildStateConf([]string{}, []string{string(SnapshotCreatingAccomplished)}, d.Timeout(schema.TimeoutCreate), 1*time.Minute,
+			ecsService.SnapshotStateRefreshFunc(snapshot.SnapshotId, []string{string(SnapshotCreatingFailed)}))
+		if _, err := stateConf.WaitForState(); err != nil {
+			return WrapErrorf(err, IdMsg, d.Id())
+		}
+	}
+
+	stateConf := BuildStateConf([]string{}, []string{string(SnapshotCreatingAccomplished)}, d.Timeout(schema.TimeoutCreate), 5*time.Second,
+		ecsService.EcsSnapshotGroupStateRefreshFunc(d.Id(), []string{string(SnapshotCreatingFailed)}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceApsaraStackEcsSnapshotGroupRead(d, meta)
+}
+
+func resourceApsaraStackEcsSnapshotGroupRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	object, err := ecsService.DescribeEcsSnapshotGroup(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("instance_id", object.InstanceId)
+	d.Set("name", object.Name)
+	d.Set("description", object.Description)
+	d.Set("status", object.Status)
+	d.Set("instant_access", d.Get("instant_access"))
+	d.Set("instant_access_retention_days", d.Get("instant_access_retention_days"))
+
+	diskIds := make([]string, 0, len(object.Snapshots.Snapshot))
+	snapshots := make([]map[string]interface{}, 0, len(object.Snapshots.Snapshot))
+	for _, snapshot := range object.Snapshots.Snapshot {
+		diskIds = append(diskIds, snapshot.SourceDiskId)
+		snapshots = append(snapshots, map[string]interface{}{
+			"snapshot_id": snapshot.SnapshotId,
+			"disk_id":     snapshot.SourceDiskId,
+			"status":      snapshot.Status,
+			"progress":    snapshot.Progress,
+		})
+	}
+	if _, ok := d.GetOk("exclude_disk_ids"); !ok {
+		d.Set("disk_ids", diskIds)
+	}
+	if err := d.Set("snapshots", snapshots); err != nil {
+		return WrapError(err)
+	}
+	return nil
+}
+
+func resourceApsaraStackEcsSnapshotGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	if d.HasChange("name") || d.HasChange("description") {
+		request := ecs.CreateModifySnapshotGroupRequest()
+		request.RegionId = client.RegionId
+		if strings.ToLower(client.Config.Protocol) == "https" {
+			request.Scheme = "https"
+		} else {
+			request.Scheme = "http"
+		}
+		request.Headers = map[string]string{"RegionId": client.RegionId}
+		request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+		request.SnapshotGroupId = d.Id()
+		request.Name = d.Get("name").(string)
+		request.Description = d.Get("description").(string)
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifySnapshotGroup(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	}
+	return resourceApsaraStackEcsSnapshotGroupRead(d, meta)
+}
+
+func resourceApsaraStackEcsSnapshotGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+
+	request := ecs.CreateDeleteSnapshotGroupRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.SnapshotGroupId = d.Id()
+
+	var raw interface{}
+	var err error
+	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		raw, err = client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DeleteSnapshotGroup(request)
+		})
+		if err != nil {
+			if NeedRetry(err) || IsExpectedErrors(err, SnapshotInvalidOperations) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		if IsExpectedErrors(err, []string{"InvalidSnapshotGroupId.NotFound"}) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+
+	stateConf := BuildStateConf([]string{}, []string{}, d.Timeout(schema.TimeoutDelete), 5*time.Second,
+		ecsService.EcsSnapshotGroupStateRefreshFunc(d.Id(), []string{}))
+	if _, err = stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
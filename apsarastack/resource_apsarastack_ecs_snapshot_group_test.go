@@ -0,0 +1,75 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackEcsSnapshotGroup_basic(t *testing.T) {
+	var v ecs.SnapshotGroup
+	resourceId := "apsarastack_ecs_snapshot_group.default"
+	rand := acctest.RandInt()
+	name := fmt.Sprintf("tf-testAccSnapshotGroup%d", rand)
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"instance_id": CHECKSET,
+		"status":      "accomplished",
+	})
+	serviceFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceSnapshotConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"instance_id": "${apsarastack_instance.default.id}",
+					"disk_ids":    "${apsarastack_disk_attachment.default.*.disk_id}",
+					"name":        "${var.name}",
+					"description": "${var.name}",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"disk_ids.#":              "2",
+						"snapshots.#":             "2",
+						"snapshots.0.snapshot_id": CHECKSET,
+						"snapshots.0.status":      "accomplished",
+						"name":                    name,
+						"description":             name,
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"name":        "${var.name}_update",
+					"description": "${var.name}_update",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"name":        name + "_update",
+						"description": name + "_update",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -1,6 +1,7 @@
 package apsarastack
 
 import (
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
 	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
@@ -37,6 +38,35 @@ func resourceApsaraStackEip() *schema.Resource {
 				Optional: true,
 				Default:  5,
 			},
+			"internet_charge_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"PayByBandwidth", "PayByTraffic"}, false),
+			},
+			"isp": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			// The pool is not returned by DescribeEipAddresses, so it keeps the configured value and is not imported.
+			// An imported EIP has no pool in its state, which must not replace it.
+			"public_ip_address_pool_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return old == "" && d.Id() != ""
+				},
+			},
+			"deletion_protection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"ip_address": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -45,6 +75,12 @@ func resourceApsaraStackEip() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags": tagsSchema(),
+			"resource_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -64,6 +100,16 @@ func resourceApsaraStackEipCreate(d *schema.ResourceData, meta interface{}) erro
 
 	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
 	request.Bandwidth = strconv.Itoa(d.Get("bandwidth").(int))
+	if v, ok := d.GetOk("resource_group_id"); ok {
+		request.ResourceGroupId = v.(string)
+	}
+	if v, ok := d.GetOk("internet_charge_type"); ok {
+		request.InternetChargeType = v.(string)
+	}
+	if v, ok := d.GetOk("isp"); ok {
+		request.ISP = v.(string)
+	}
+	setExtraQueryParams(request.QueryParams, map[string]string{"PublicIpAddressPoolId": d.Get("public_ip_address_pool_id").(string)})
 	request.ClientToken = buildClientToken(request.GetActionName())
 
 	raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
@@ -102,11 +148,53 @@ func resourceApsaraStackEipRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("bandwidth", bandwidth)
 	d.Set("ip_address", object.IpAddress)
 	d.Set("status", object.Status)
+	d.Set("resource_group_id", object.ResourceGroupId)
+	d.Set("internet_charge_type", object.InternetChargeType)
+	d.Set("isp", object.ISP)
+	d.Set("deletion_protection", object.DeletionProtection)
+	tags, err := vpcService.DescribeTags(d.Id(), nil, TagResourceEip)
+	if err != nil {
+		return WrapError(err)
+	}
+	d.Set("tags", vpcService.tagsToMap(tags))
 	return nil
 }
 
 func resourceApsaraStackEipUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	if err := vpcService.setInstanceTags(d, TagResourceEip); err != nil {
+		return WrapError(err)
+	}
+	if !d.IsNewResource() {
+		if err := vpcService.setResourceGroup(d, "eip"); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	if d.HasChange("deletion_protection") {
+		request := vpc.CreateDeletionProtectionRequest()
+		request.RegionId = client.RegionId
+		if strings.ToLower(client.Config.Protocol) == "https" {
+			request.Scheme = "https"
+		} else {
+			request.Scheme = "http"
+		}
+		request.Headers = map[string]string{"RegionId": client.RegionId}
+		request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+		request.InstanceId = d.Id()
+		request.Type = "EIP"
+		request.ProtectionEnable = requests.NewBoolean(d.Get("deletion_protection").(bool))
+		request.ClientToken = buildClientToken(request.GetActionName())
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeletionProtection(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	}
+
 	update := false
 	request := vpc.CreateModifyEipAddressAttributeRequest()
 	request.RegionId = client.RegionId
@@ -9,6 +9,7 @@ import (
 	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
 func resourceApsaraStackEipAssociation() *schema.Resource {
@@ -37,10 +38,11 @@ func resourceApsaraStackEipAssociation() *schema.Resource {
 			},
 
 			"instance_type": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{EcsInstance, SlbInstance, Nat, HaVip, "NetworkInterface"}, false),
 			},
 		},
 	}
@@ -70,6 +72,9 @@ func resourceApsaraStackEipAssociationCreate(d *schema.ResourceData, meta interf
 	if strings.HasPrefix(request.InstanceId, "ngw-") {
 		request.InstanceType = Nat
 	}
+	if strings.HasPrefix(request.InstanceId, "havip-") {
+		request.InstanceType = HaVip
+	}
 	if instanceType, ok := d.GetOk("instance_type"); ok {
 		request.InstanceType = instanceType.(string)
 	}
@@ -78,7 +83,7 @@ func resourceApsaraStackEipAssociationCreate(d *schema.ResourceData, meta interf
 			return vpcClient.AssociateEipAddress(request)
 		})
 		if err != nil {
-			if IsExpectedErrors(err, []string{"TaskConflict"}) {
+			if IsExpectedErrors(err, []string{"TaskConflict", "IncorrectHaVipStatus"}) {
 				return resource.RetryableError(err)
 			}
 			return resource.NonRetryableError(err)
@@ -155,6 +160,9 @@ func resourceApsaraStackEipAssociationDelete(d *schema.ResourceData, meta interf
 	if strings.HasPrefix(instanceId, "ngw-") {
 		request.InstanceType = Nat
 	}
+	if strings.HasPrefix(instanceId, "havip-") {
+		request.InstanceType = HaVip
+	}
 	if instanceType, ok := d.GetOk("instance_type"); ok {
 		request.InstanceType = instanceType.(string)
 	}
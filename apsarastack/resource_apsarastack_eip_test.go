@@ -206,6 +206,32 @@ func TestAccApsaraStackEipBasic_PayByBandwidth(t *testing.T) {
 					}),
 				),
 			},
+			{
+				Config: testAccCheckEipConfig_tags(rand),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"tags.%":       "2",
+						"tags.Created": "TF",
+						"tags.For":     "acceptance test",
+					}),
+				),
+			},
+			{
+				Config: testAccCheckEipConfig_deletionProtection(rand, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"deletion_protection": "true",
+					}),
+				),
+			},
+			{
+				Config: testAccCheckEipConfig_deletionProtection(rand, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"deletion_protection": "false",
+					}),
+				),
+			},
 		},
 	})
 
@@ -236,9 +262,11 @@ func TestAccApsaraStackEipBasic_PayByTraffic(t *testing.T) {
 		CheckDestroy: testAccCheckEIPDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccCheckEipConfigBasic(rand),
+				Config: testAccCheckEipConfig_payByTraffic(rand),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheck(map[string]string{}),
+					testAccCheck(map[string]string{
+						"internet_charge_type": "PayByTraffic",
+					}),
 				),
 			},
 			{
@@ -369,6 +397,50 @@ resource "apsarastack_eip" "default" {
 `, rand)
 }
 
+func testAccCheckEipConfig_tags(rand int) string {
+	return fmt.Sprintf(`
+variable "name"{
+	default = "tf-testAcceEipName%d"
+}
+resource "apsarastack_eip" "default" {
+	bandwidth = "10"
+	name = "${var.name}_all"
+    description = "${var.name}_description_all"
+	tags = {
+		Created = "TF"
+		For     = "acceptance test"
+	}
+}
+`, rand)
+}
+
+func testAccCheckEipConfig_deletionProtection(rand int, enabled bool) string {
+	return fmt.Sprintf(`
+variable "name"{
+	default = "tf-testAcceEipName%d"
+}
+resource "apsarastack_eip" "default" {
+	bandwidth = "10"
+	name = "${var.name}_all"
+	description = "${var.name}_description_all"
+	deletion_protection = %t
+	tags = {
+		Created = "TF"
+		For     = "acceptance test"
+	}
+}
+`, rand, enabled)
+}
+
+func testAccCheckEipConfig_payByTraffic(rand int) string {
+	return fmt.Sprintf(`
+resource "apsarastack_eip" "default" {
+	bandwidth = "5"
+	internet_charge_type = "PayByTraffic"
+}
+`)
+}
+
 func testAccCheckEipConfig_multi(rand int) string {
 	return fmt.Sprintf(`
 resource "apsarastack_eip" "default" {
@@ -117,10 +117,12 @@ func resourceApsaraStackEssScalingConfiguration() *schema.Resource {
 						"encrypted": {
 							Type:     schema.TypeString,
 							Optional: true,
+							Computed: true,
 						},
 						"kms_key_id": {
 							Type:     schema.TypeString,
 							Optional: true,
+							Computed: true,
 						},
 						"delete_with_instance": {
 							Type:     schema.TypeBool,
@@ -388,10 +390,9 @@ func modifyEssScalingConfiguration(d *schema.ResourceData, meta interface{}) err
 					Size:               strconv.Itoa(pack["size"].(int)),
 					Category:           pack["category"].(string),
 					SnapshotId:         pack["snapshot_id"].(string),
-					Encrypted:          pack["encrypted"].(string),
-					KMSKeyId:           pack["kms_key_id"].(string),
 					DeleteWithInstance: strconv.FormatBool(pack["delete_with_instance"].(bool)),
 				}
+				dataDisk.Encrypted, dataDisk.KMSKeyId = essDataDiskEncryption(client, pack)
 				createDataDisks = append(createDataDisks, dataDisk)
 			}
 			request.DataDisk = &createDataDisks
@@ -710,6 +711,7 @@ func buildApsaraStackEssScalingConfigurationArgs(d *schema.ResourceData, meta in
 	if v := d.Get("system_disk_size").(int); v != 0 {
 		request.SystemDiskSize = requests.NewInteger(v)
 	}
+	setDefaultSystemDiskEncryption(client, request.QueryParams)
 
 	dds, ok := d.GetOk("data_disk")
 	if ok {
@@ -721,10 +723,9 @@ func buildApsaraStackEssScalingConfigurationArgs(d *schema.ResourceData, meta in
 				Size:               strconv.Itoa(pack["size"].(int)),
 				Category:           pack["category"].(string),
 				SnapshotId:         pack["snapshot_id"].(string),
-				Encrypted:          pack["encrypted"].(string),
-				KMSKeyId:           pack["kms_key_id"].(string),
 				DeleteWithInstance: strconv.FormatBool(pack["delete_with_instance"].(bool)),
 			}
+			dataDisk.Encrypted, dataDisk.KMSKeyId = essDataDiskEncryption(client, pack)
 			createDataDisks = append(createDataDisks, dataDisk)
 		}
 		request.DataDisk = &createDataDisks
@@ -823,3 +824,17 @@ func activeSubstituteScalingConfiguration(d *schema.ResourceData, meta interface
 
 	return response.ScalingConfigurations.ScalingConfiguration, nil
 }
+
+// essDataDiskEncryption returns the encryption of a data disk, falling back to the provider default_disk_encryption
+// for the disks which are not encrypted and are not created from a snapshot.
+func essDataDiskEncryption(client *connectivity.ApsaraStackClient, disk map[string]interface{}) (encrypted, kmsKeyId string) {
+	encrypted, kmsKeyId = disk["encrypted"].(string), disk["kms_key_id"].(string)
+	if client.Config.DefaultDiskKMSKeyId == "" || disk["snapshot_id"].(string) != "" {
+		return
+	}
+	encrypted = "true"
+	if kmsKeyId == "" {
+		kmsKeyId = client.Config.DefaultDiskKMSKeyId
+	}
+	return
+}
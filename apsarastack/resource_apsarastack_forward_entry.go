@@ -16,6 +16,9 @@ func resourceApsaraStackForwardEntry() *schema.Resource {
 		Read:   resourceApsaraStackForwardEntryRead,
 		Update: resourceApsaraStackForwardEntryUpdate,
 		Delete: resourceApsaraStackForwardEntryDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"forward_table_id": {
@@ -0,0 +1,197 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceApsaraStackHaVipAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackHaVipAttachmentCreate,
+		Read:   resourceApsaraStackHaVipAttachmentRead,
+		Update: resourceApsaraStackHaVipAttachmentUpdate,
+		Delete: resourceApsaraStackHaVipAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"havip_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"instance_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{EcsInstance, "NetworkInterface"}, false),
+			},
+			"force": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceApsaraStackHaVipAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateAssociateHaVipRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.HaVipId = d.Get("havip_id").(string)
+	request.InstanceId = d.Get("instance_id").(string)
+	request.InstanceType = getHaVipAttachmentInstanceType(d)
+
+	wait := incrementalWait(1*time.Second, 1*time.Second)
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		request.ClientToken = buildClientToken(request.GetActionName())
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.AssociateHaVip(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"IncorrectHaVipStatus", "IncorrectInstanceStatus", "TaskConflict", "OperationConflict", Throttling}) {
+				wait()
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "apsarastack_havip_attachment", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	d.SetId(fmt.Sprintf("%s%s%s", request.HaVipId, COLON_SEPARATED, request.InstanceId))
+
+	stateConf := BuildStateConf([]string{}, []string{string(InUse)}, d.Timeout(schema.TimeoutCreate), 3*time.Second, vpcService.HaVipAttachmentStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceApsaraStackHaVipAttachmentRead(d, meta)
+}
+
+func resourceApsaraStackHaVipAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	parts, err := ParseResourceId(d.Id(), 2)
+	if err != nil {
+		return WrapError(err)
+	}
+	object, err := vpcService.DescribeHaVipAttachment(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("havip_id", object.HaVipId)
+	d.Set("instance_id", parts[1])
+	if strings.HasPrefix(parts[1], "eni-") {
+		d.Set("instance_type", "NetworkInterface")
+	} else {
+		d.Set("instance_type", EcsInstance)
+	}
+	d.Set("force", d.Get("force").(bool))
+	return nil
+}
+
+// force only affects how the instance is unbound, so there is nothing to send on update.
+func resourceApsaraStackHaVipAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceApsaraStackHaVipAttachmentRead(d, meta)
+}
+
+func resourceApsaraStackHaVipAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	parts, err := ParseResourceId(d.Id(), 2)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	request := vpc.CreateUnassociateHaVipRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.HaVipId = parts[0]
+	request.InstanceId = parts[1]
+	request.InstanceType = getHaVipAttachmentInstanceType(d)
+	// Force unbinds the HaVip even if it is still the next hop of a route entry or bound to an EIP.
+	if d.Get("force").(bool) {
+		request.Force = "True"
+	} else {
+		request.Force = "False"
+	}
+
+	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		request.ClientToken = buildClientToken(request.GetActionName())
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.UnassociateHaVip(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"InvalidHaVipId.NotFound"}) {
+				return nil
+			}
+			if IsExpectedErrors(err, []string{"IncorrectHaVipStatus", "IncorrectInstanceStatus", "TaskConflict", "OperationConflict", Throttling}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{string(InUse)}, []string{}, d.Timeout(schema.TimeoutDelete), 3*time.Second, vpcService.HaVipAttachmentStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
+
+func getHaVipAttachmentInstanceType(d *schema.ResourceData) string {
+	if v, ok := d.GetOk("instance_type"); ok && v.(string) != "" {
+		return v.(string)
+	}
+	if strings.HasPrefix(d.Get("instance_id").(string), "eni-") {
+		return "NetworkInterface"
+	}
+	return EcsInstance
+}
@@ -0,0 +1,83 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackHaVipAttachment_basic(t *testing.T) {
+	var v vpc.HaVip
+	rand := acctest.RandIntRange(10000, 99999)
+	resourceId := "apsarastack_havip_attachment.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"havip_id":      CHECKSET,
+		"instance_id":   CHECKSET,
+		"instance_type": "EcsInstance",
+		"force":         "false",
+	})
+	serviceFunc := func() interface{} {
+		return &VpcService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInitWithDescribeMethod(resourceId, &v, serviceFunc, "DescribeHaVipAttachment")
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		// module name
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHaVipAttachmentConfig(rand),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:            resourceId,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force"},
+			},
+		},
+	})
+}
+
+func testAccHaVipAttachmentConfig(rand int) string {
+	return fmt.Sprintf(`
+%s
+
+variable "name" {
+  default = "tf-testAccHaVipAttachment%d"
+}
+
+resource "apsarastack_instance" "default" {
+  vswitch_id = "${apsarastack_vswitch.default.id}"
+  image_id = "${data.apsarastack_images.default.images.0.id}"
+  availability_zone = "${data.apsarastack_zones.default.zones.0.id}"
+  system_disk_category = "cloud_efficiency"
+  instance_type = "${data.apsarastack_instance_types.default.instance_types.0.id}"
+  security_groups = ["${apsarastack_security_group.default.id}"]
+  instance_name = "${var.name}"
+}
+
+resource "apsarastack_havip" "default" {
+  vswitch_id = "${apsarastack_vswitch.default.id}"
+  name = "${var.name}"
+}
+
+resource "apsarastack_havip_attachment" "default" {
+  havip_id = "${apsarastack_havip.default.id}"
+  instance_id = "${apsarastack_instance.default.id}"
+}
+`, EcsInstanceCommonTestCase, rand)
+}
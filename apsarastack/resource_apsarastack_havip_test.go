@@ -0,0 +1,94 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackHaVip_basic(t *testing.T) {
+	var v vpc.HaVip
+	rand := acctest.RandIntRange(10000, 99999)
+	resourceId := "apsarastack_havip.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"vswitch_id":  CHECKSET,
+		"vpc_id":      CHECKSET,
+		"ip_address":  "172.16.0.10",
+		"name":        fmt.Sprintf("tf-testAccHaVip%d", rand),
+		"description": "tf-testAccHaVip",
+		"status":      "Available",
+	})
+	serviceFunc := func() interface{} {
+		return &VpcService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInitWithDescribeMethod(resourceId, &v, serviceFunc, "DescribeHaVip")
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		// module name
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHaVipConfig(rand, fmt.Sprintf("tf-testAccHaVip%d", rand), "tf-testAccHaVip"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccHaVipConfig(rand, fmt.Sprintf("tf-testAccHaVip%d_change", rand), "tf-testAccHaVip_change"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"name":        fmt.Sprintf("tf-testAccHaVip%d_change", rand),
+						"description": "tf-testAccHaVip_change",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccHaVipConfig(rand int, name, description string) string {
+	return fmt.Sprintf(`
+data "apsarastack_zones" "default" {
+  available_resource_creation = "VSwitch"
+}
+
+variable "name" {
+  default = "tf-testAccHaVip%d"
+}
+
+resource "apsarastack_vpc" "default" {
+  name = "${var.name}"
+  cidr_block = "172.16.0.0/16"
+}
+
+resource "apsarastack_vswitch" "default" {
+  vpc_id = "${apsarastack_vpc.default.id}"
+  cidr_block = "172.16.0.0/24"
+  availability_zone = "${data.apsarastack_zones.default.zones.0.id}"
+  name = "${var.name}"
+}
+
+resource "apsarastack_havip" "default" {
+  vswitch_id = "${apsarastack_vswitch.default.id}"
+  ip_address = "172.16.0.10"
+  name = "%s"
+  description = "%s"
+}
+`, rand, name, description)
+}
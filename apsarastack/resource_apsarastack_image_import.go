@@ -1,11 +1,17 @@
 package apsarastack
 
 import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
@@ -84,16 +90,33 @@ func resourceApsaraStackImageImport() *schema.Resource {
 					"linux",
 				}, false),
 			},
+			"format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"qcow2", "raw", "vhd"}, true),
+			},
+			"validate_oss_object": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
 			"disk_device_mapping": {
 				Type:     schema.TypeList,
 				ForceNew: true,
 				Required: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						"device": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Computed: true,
+						},
 						"disk_image_size": {
 							Type:     schema.TypeInt,
 							Optional: true,
-							Default:  5,
+							Computed: true,
 							ForceNew: true,
 						},
 						"format": {
@@ -120,6 +143,22 @@ func resourceApsaraStackImageImport() *schema.Resource {
 					},
 				},
 			},
+			"task_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"task_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"task_progress": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"task_failure_reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -149,11 +188,19 @@ func resourceApsaraStackImageImportCreate(d *schema.ResourceData, meta interface
 		mappings := make([]ecs.ImportImageDiskDeviceMapping, 0, len(diskDeviceMappings))
 		for _, diskDeviceMapping := range diskDeviceMappings {
 			mapping := diskDeviceMapping.(map[string]interface{})
-			size := strconv.Itoa(mapping["disk_image_size"].(int))
+			format, err := imageImportDiskFormat(d, meta, mapping)
+			if err != nil {
+				return WrapError(err)
+			}
+			// Without a disk_image_size the size is detected from the image.
+			size := ""
+			if v := mapping["disk_image_size"].(int); v > 0 {
+				size = strconv.Itoa(v)
+			}
 			diskmapping := ecs.ImportImageDiskDeviceMapping{
 				Device:        mapping["device"].(string),
 				DiskImageSize: size,
-				Format:        mapping["format"].(string),
+				Format:        format,
 				OSSBucket:     mapping["oss_bucket"].(string),
 				OSSObject:     mapping["oss_object"].(string),
 			}
@@ -171,6 +218,25 @@ func resourceApsaraStackImageImportCreate(d *schema.ResourceData, meta interface
 	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
 	resp, _ := raw.(*ecs.ImportImageResponse)
 	d.SetId(resp.ImageId)
+	d.Set("task_id", resp.TaskId)
+
+	if resp.TaskId != "" {
+		refresh := ecsService.TaskStateRefreshFunc(resp.TaskId, []string{"Failed", "Cancelled", "Deleted"})
+		stateConf := BuildStateConf([]string{"Waiting", "Processing", "Paused", ""}, []string{"Finished"}, d.Timeout(schema.TimeoutCreate), 1*time.Minute, func() (interface{}, string, error) {
+			object, status, err := refresh()
+			if task, ok := object.(*ecs.DescribeTaskAttributeResponse); ok && task != nil {
+				log.Printf("[INFO] Importing image %s: task %s is %s, progress %s", d.Id(), task.TaskId, task.TaskStatus, task.TaskProcess)
+				setImageImportTask(d, task)
+			}
+			return object, status, err
+		})
+		if _, err := stateConf.WaitForState(); err != nil {
+			if reason := d.Get("task_failure_reason").(string); reason != "" {
+				return WrapErrorf(err, "The import task %s of image %s failed: %s", resp.TaskId, d.Id(), reason)
+			}
+			return WrapErrorf(err, IdMsg, d.Id())
+		}
+	}
 	stateConf := BuildStateConf([]string{"Waiting"}, []string{"Available"}, d.Timeout(schema.TimeoutCreate), 1*time.Minute, ecsService.ImageStateRefreshFunc(d.Id(), []string{"CreateFailed", "UnAvailable"}))
 	if _, err := stateConf.WaitForState(); err != nil {
 		return WrapErrorf(err, IdMsg, d.Id())
@@ -197,6 +263,16 @@ func resourceApsaraStackImageImportRead(d *schema.ResourceData, meta interface{}
 	d.Set("platform", object.Platform)
 	d.Set("disk_device_mapping", FlattenImageImportDiskDeviceMappings(object.DiskDeviceMappings.DiskDeviceMapping))
 
+	// The import task is kept by ECS for a limited time only.
+	if taskId, ok := d.GetOk("task_id"); ok {
+		task, err := ecsService.DescribeTaskById(taskId.(string))
+		if err != nil && !NotFoundError(err) {
+			return WrapError(err)
+		}
+		if err == nil {
+			setImageImportTask(d, task)
+		}
+	}
 	return nil
 }
 
@@ -207,7 +283,7 @@ func resourceApsaraStackImageImportUpdate(d *schema.ResourceData, meta interface
 	if err != nil {
 		return WrapError(err)
 	}
-	return resourceApsaraStackImageRead(d, meta)
+	return resourceApsaraStackImageImportRead(d, meta)
 }
 
 func resourceApsaraStackImageImportDelete(d *schema.ResourceData, meta interface{}) error {
@@ -231,3 +307,123 @@ func FlattenImageImportDiskDeviceMappings(list []ecs.DiskDeviceMapping) []map[st
 	}
 	return result
 }
+
+func setImageImportTask(d *schema.ResourceData, task *ecs.DescribeTaskAttributeResponse) {
+	d.Set("task_status", task.TaskStatus)
+	d.Set("task_progress", task.TaskProcess)
+	var reasons []string
+	for _, progress := range task.OperationProgressSet.OperationProgress {
+		if progress.ErrorCode != "" || progress.ErrorMsg != "" {
+			reasons = append(reasons, strings.TrimSpace(fmt.Sprintf("%s %s", progress.ErrorCode, progress.ErrorMsg)))
+		}
+	}
+	d.Set("task_failure_reason", strings.Join(reasons, "; "))
+}
+
+// imageImportDiskFormat returns the format of a disk image, taken from the disk device mapping, the resource or
+// the extension of the OSS object, in that order. Unless validate_oss_object is false, it also makes sure the OSS
+// object exists, is large enough and its content matches the format.
+func imageImportDiskFormat(d *schema.ResourceData, meta interface{}, mapping map[string]interface{}) (string, error) {
+	format := mapping["format"].(string)
+	if format == "" {
+		format = d.Get("format").(string)
+	}
+	bucketName := mapping["oss_bucket"].(string)
+	objectName := mapping["oss_object"].(string)
+	if format == "" {
+		switch strings.ToLower(path.Ext(objectName)) {
+		case ".qcow2":
+			format = "qcow2"
+		case ".raw", ".img":
+			format = "raw"
+		case ".vhd":
+			format = "vhd"
+		}
+	}
+	format = normalizeImageImportFormat(format)
+
+	if !d.Get("validate_oss_object").(bool) || bucketName == "" || objectName == "" {
+		return format, nil
+	}
+
+	client := meta.(*connectivity.ApsaraStackClient)
+	raw, err := client.WithOssBucketByName(bucketName, func(bucket *oss.Bucket) (interface{}, error) {
+		return bucket, nil
+	})
+	if err != nil {
+		return "", WrapErrorf(err, DefaultErrorMsg, bucketName, "Bucket", ApsaraStackOssGoSdk)
+	}
+	bucket, _ := raw.(*oss.Bucket)
+
+	header, err := bucket.GetObjectDetailedMeta(objectName)
+	if err != nil {
+		if ossNotFoundError(err) {
+			return "", WrapError(Error("The OSS object %s does not exist in the bucket %s.", objectName, bucketName))
+		}
+		return "", WrapErrorf(err, DefaultErrorMsg, objectName, "GetObjectDetailedMeta", ApsaraStackOssGoSdk)
+	}
+	addDebug("GetObjectDetailedMeta", header, bucket, map[string]string{"objectKey": objectName})
+	size, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+	if err != nil || size <= 0 {
+		return "", WrapError(Error("The OSS object %s in the bucket %s is empty.", objectName, bucketName))
+	}
+	detected, err := detectImageImportFormat(bucket, objectName, size)
+	if err != nil {
+		return "", WrapError(err)
+	}
+	if format == "" {
+		format = detected
+	}
+	if detected != format {
+		return "", WrapError(Error("The OSS object %s is a %s image, but the format %s was specified.", objectName, detected, format))
+	}
+	// A raw image is as large as the disk it is imported into, other formats are compressed. The check only
+	// applies to a configured disk_image_size, otherwise the size is detected from the image.
+	if diskSize := mapping["disk_image_size"].(int); format == "RAW" && diskSize > 0 && size > int64(diskSize)<<30 {
+		return "", WrapError(Error("The raw OSS object %s is %d bytes, which exceeds the disk_image_size of %d GiB.", objectName, size, diskSize))
+	}
+	return format, nil
+}
+
+// detectImageImportFormat reads the header and footer of a disk image to tell qcow2 and VHD images from raw ones.
+func detectImageImportFormat(bucket *oss.Bucket, objectName string, size int64) (string, error) {
+	readRange := func(start, end int64) ([]byte, error) {
+		body, err := bucket.GetObject(objectName, oss.Range(start, end))
+		if err != nil {
+			return nil, WrapErrorf(err, DefaultErrorMsg, objectName, "GetObject", ApsaraStackOssGoSdk)
+		}
+		defer body.Close()
+		return ioutil.ReadAll(body)
+	}
+
+	head, err := readRange(0, 3)
+	if err != nil {
+		return "", err
+	}
+	if bytes.Equal(head, []byte{'Q', 'F', 'I', 0xfb}) {
+		return "qcow2", nil
+	}
+	if size >= 512 {
+		footer, err := readRange(size-512, size-1)
+		if err != nil {
+			return "", err
+		}
+		if bytes.HasPrefix(footer, []byte("conectix")) {
+			return "VHD", nil
+		}
+	}
+	return "RAW", nil
+}
+
+// normalizeImageImportFormat converts a format to the value expected by ImportImage.
+func normalizeImageImportFormat(format string) string {
+	switch strings.ToLower(format) {
+	case "qcow2":
+		return "qcow2"
+	case "raw":
+		return "RAW"
+	case "vhd":
+		return "VHD"
+	}
+	return format
+}
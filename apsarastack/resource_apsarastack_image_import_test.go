@@ -59,6 +59,10 @@ func TestAccApsaraStackImportImage(t *testing.T) {
 						"disk_device_mapping.#":            "1",
 						"disk_device_mapping.0.oss_bucket": CHECKSET,
 						"disk_device_mapping.0.oss_object": CHECKSET,
+						"disk_device_mapping.0.format":     CHECKSET,
+						"task_id":                          CHECKSET,
+						"task_status":                      "Finished",
+						"task_failure_reason":              "",
 					}),
 				),
 			},
@@ -98,7 +102,7 @@ func TestAccApsaraStackImportImage(t *testing.T) {
 				ResourceName:            resourceId,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"license_type"},
+				ImportStateVerifyIgnore: []string{"license_type", "validate_oss_object", "task_id", "task_status", "task_progress", "task_failure_reason"},
 			},
 		},
 	})
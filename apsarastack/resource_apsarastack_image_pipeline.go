@@ -0,0 +1,668 @@
+package apsarastack
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+const (
+	ImagePipelineBuildByCloudAssistant = "CloudAssistant"
+	ImagePipelineBuildByUserData       = "UserData"
+)
+
+func resourceApsaraStackImagePipeline() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackImagePipelineCreate,
+		Read:   resourceApsaraStackImagePipelineRead,
+		Update: resourceApsaraStackImagePipelineUpdate,
+		Delete: resourceApsaraStackImagePipelineDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"image_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"instance_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"vswitch_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"security_group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"system_disk_category": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      string(DiskCloudEfficiency),
+				ValidateFunc: validation.StringInSlice([]string{string(DiskCloudEfficiency), string(DiskCloudSSD), string(DiskCloudESSD)}, false),
+			},
+			"system_disk_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(20, 500),
+			},
+			"internet_max_bandwidth_out": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(0, 100),
+			},
+			"build_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      ImagePipelineBuildByCloudAssistant,
+				ValidateFunc: validation.StringInSlice([]string{ImagePipelineBuildByCloudAssistant, ImagePipelineBuildByUserData}, false),
+			},
+			"build_script": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"build_script_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "RunShellScript",
+				ValidateFunc: validation.StringInSlice([]string{"RunShellScript", "RunBatScript", "RunPowerShellScript"}, false),
+			},
+			"build_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      3600,
+				ValidateFunc: validation.IntAtLeast(60),
+			},
+			"image_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringLenBetween(2, 128),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 256),
+			},
+			"tags": tagsSchema(),
+			"copy_to_region_ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"share_account_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"force": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"disk_device_mapping": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"snapshot_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"copied_images": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"image_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"build_output": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceApsaraStackImagePipelineCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+
+	builderId, err := runImagePipelineBuilder(d, meta)
+	// The builder instance is only needed to bake the image, it is always released even if the build fails.
+	if builderId != "" {
+		defer func() {
+			if err := deleteImagePipelineBuilder(client, builderId, d.Timeout(schema.TimeoutCreate)); err != nil {
+				log.Printf("[WARN] Failed to release the image pipeline builder instance %s: %#v", builderId, err)
+			}
+		}()
+	}
+	if err != nil {
+		return WrapError(err)
+	}
+
+	output, err := buildImagePipelineBuilder(d, meta, builderId)
+	if err != nil {
+		return WrapError(err)
+	}
+	d.Set("build_output", output)
+
+	if err := stopImagePipelineBuilder(client, builderId, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return WrapError(err)
+	}
+
+	request := ecs.CreateCreateImageRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.InstanceId = builderId
+	request.ImageName = d.Get("image_name").(string)
+	request.Description = d.Get("description").(string)
+	if tags := d.Get("tags").(map[string]interface{}); len(tags) > 0 {
+		imageTags := make([]ecs.CreateImageTag, 0, len(tags))
+		for k, v := range tags {
+			imageTags = append(imageTags, ecs.CreateImageTag{
+				Key:   k,
+				Value: v.(string),
+			})
+		}
+		request.Tag = &imageTags
+	}
+	err = resource.Retry(5*time.Minute, func() *resource.RetryError {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.CreateImage(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"IncorrectInstanceStatus"}) {
+				time.Sleep(time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*ecs.CreateImageResponse)
+		d.SetId(response.ImageId)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "apsarastack_image_pipeline", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{"Creating", "Waiting", ""}, []string{"Available"}, d.Timeout(schema.TimeoutCreate), 1*time.Minute, ecsService.ImageStateRefreshFunc(d.Id(), []string{"CreateFailed", "UnAvailable"}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	if err := copyImagePipelineImage(d, meta); err != nil {
+		return WrapError(err)
+	}
+	if v, ok := d.GetOk("share_account_ids"); ok {
+		if err := modifyImagePipelineSharePermission(client, d.Id(), expandStringList(v.(*schema.Set).List()), nil); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	return resourceApsaraStackImagePipelineRead(d, meta)
+}
+
+func resourceApsaraStackImagePipelineRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	object, err := ecsService.DescribeImageById(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("image_name", object.ImageName)
+	d.Set("description", object.Description)
+	d.Set("disk_device_mapping", FlattenImageDiskDeviceMappings(object.DiskDeviceMappings.DiskDeviceMapping))
+	if len(object.Tags.Tag) > 0 {
+		if err := d.Set("tags", ecsService.tagsToMap(object.Tags.Tag)); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	if _, ok := d.GetOk("share_account_ids"); ok {
+		accounts, err := describeImagePipelineShareAccounts(client, d.Id())
+		if err != nil {
+			return WrapError(err)
+		}
+		d.Set("share_account_ids", accounts)
+	}
+	return nil
+}
+
+func resourceApsaraStackImagePipelineUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+
+	if err := ecsService.updateImage(d); err != nil {
+		return WrapError(err)
+	}
+
+	if d.HasChange("share_account_ids") {
+		o, n := d.GetChange("share_account_ids")
+		oldAccounts := o.(*schema.Set)
+		newAccounts := n.(*schema.Set)
+		add := expandStringList(newAccounts.Difference(oldAccounts).List())
+		remove := expandStringList(oldAccounts.Difference(newAccounts).List())
+		if err := modifyImagePipelineSharePermission(client, d.Id(), add, remove); err != nil {
+			return WrapError(err)
+		}
+	}
+	return resourceApsaraStackImagePipelineRead(d, meta)
+}
+
+func resourceApsaraStackImagePipelineDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+
+	for _, v := range d.Get("copied_images").([]interface{}) {
+		copied := v.(map[string]interface{})
+		regionId := copied["region_id"].(string)
+		imageId := copied["image_id"].(string)
+		request := ecs.CreateDeleteImageRequest()
+		if strings.ToLower(client.Config.Protocol) == "https" {
+			request.Scheme = "https"
+		} else {
+			request.Scheme = "http"
+		}
+		request.RegionId = regionId
+		request.Headers = map[string]string{"RegionId": client.RegionId}
+		request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+		request.ImageId = imageId
+		request.Force = requests.NewBoolean(d.Get("force").(bool))
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DeleteImage(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"InvalidImageId.NotFound"}) {
+				continue
+			}
+			return WrapErrorf(err, DefaultErrorMsg, imageId, request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	}
+
+	// A shared image can not be deleted until it stops being shared.
+	if v, ok := d.GetOk("share_account_ids"); ok {
+		if err := modifyImagePipelineSharePermission(client, d.Id(), nil, expandStringList(v.(*schema.Set).List())); err != nil && !NotFoundError(err) {
+			return WrapError(err)
+		}
+	}
+	return WrapError(ecsService.deleteImage(d))
+}
+
+// runImagePipelineBuilder launches the temporary instance on which the image is baked.
+func runImagePipelineBuilder(d *schema.ResourceData, meta interface{}) (string, error) {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+
+	request := ecs.CreateRunInstancesRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.ImageId = d.Get("image_id").(string)
+	request.InstanceType = d.Get("instance_type").(string)
+	request.VSwitchId = d.Get("vswitch_id").(string)
+	request.SecurityGroupId = d.Get("security_group_id").(string)
+	request.InstanceName = fmt.Sprintf("tf-image-pipeline-builder-%d", time.Now().Unix())
+	request.SystemDiskCategory = d.Get("system_disk_category").(string)
+	if v, ok := d.GetOk("system_disk_size"); ok {
+		request.SystemDiskSize = fmt.Sprintf("%d", v.(int))
+	}
+	if v, ok := d.GetOk("internet_max_bandwidth_out"); ok {
+		request.InternetMaxBandwidthOut = requests.NewInteger(v.(int))
+	}
+	if d.Get("build_method").(string) == ImagePipelineBuildByUserData {
+		request.UserData = base64.StdEncoding.EncodeToString([]byte(d.Get("build_script").(string)))
+	}
+	request.ClientToken = buildClientToken(request.GetActionName())
+
+	var instanceId string
+	wait := incrementalWait(1*time.Second, 1*time.Second)
+	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.RunInstances(request)
+		})
+		if err != nil {
+			if IsThrottling(err) {
+				wait()
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*ecs.RunInstancesResponse)
+		if len(response.InstanceIdSets.InstanceIdSet) > 0 {
+			instanceId = response.InstanceIdSets.InstanceIdSet[0]
+		}
+		return nil
+	})
+	if err != nil {
+		return "", WrapErrorf(err, DefaultErrorMsg, "apsarastack_image_pipeline", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	if instanceId == "" {
+		return "", WrapError(Error("The builder instance of the image pipeline was not launched."))
+	}
+
+	stateConf := BuildStateConf([]string{"Pending", "Starting"}, []string{"Running"}, d.Timeout(schema.TimeoutCreate), 30*time.Second, ecsService.InstanceStateRefreshFunc(instanceId, []string{"Stopping"}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return instanceId, WrapErrorf(err, IdMsg, instanceId)
+	}
+	return instanceId, nil
+}
+
+// buildImagePipelineBuilder runs the build script on the builder instance and returns its output.
+// With UserData the script runs at boot and must shut the instance down when it finishes.
+func buildImagePipelineBuilder(d *schema.ResourceData, meta interface{}, instanceId string) (string, error) {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	timeout := time.Duration(d.Get("build_timeout").(int)) * time.Second
+
+	if d.Get("build_method").(string) == ImagePipelineBuildByUserData {
+		stateConf := BuildStateConf([]string{"Running", "Stopping"}, []string{"Stopped"}, timeout, 1*time.Minute, ecsService.InstanceStateRefreshFunc(instanceId, []string{}))
+		if _, err := stateConf.WaitForState(); err != nil {
+			return "", WrapErrorf(err, IdMsg, instanceId)
+		}
+		return "", nil
+	}
+
+	request := ecs.CreateRunCommandRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.Name = fmt.Sprintf("tf-image-pipeline-%s", instanceId)
+	request.Type = d.Get("build_script_type").(string)
+	request.CommandContent = encodeCommandContent(d.Get("build_script").(string))
+	request.ContentEncoding = "Base64"
+	request.Timeout = requests.NewInteger(d.Get("build_timeout").(int))
+	request.KeepCommand = requests.NewBoolean(false)
+	request.InstanceId = &[]string{instanceId}
+
+	var invokeId string
+	wait := incrementalWait(3*time.Second, 3*time.Second)
+	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.RunCommand(request)
+		})
+		if err != nil {
+			if NeedRetry(err) || IsExpectedErrors(err, []string{"InstanceNotRunning", "ClientNotRunning"}) {
+				wait()
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*ecs.RunCommandResponse)
+		invokeId = response.InvokeId
+		return nil
+	})
+	if err != nil {
+		return "", WrapErrorf(err, DefaultErrorMsg, instanceId, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{"Pending", "Scheduled", "Running"}, []string{"Success", "Finished"}, timeout, 10*time.Second,
+		ecsService.EcsInvocationStateRefreshFunc(invokeId, []string{"Failed", "PartialFailed", "Stopped"}))
+	_, waitErr := stateConf.WaitForState()
+
+	var output string
+	results, err := ecsService.DescribeEcsInvocationResults(invokeId)
+	if err != nil {
+		return "", WrapError(err)
+	}
+	for _, result := range results {
+		decoded, err := base64.StdEncoding.DecodeString(result.Output)
+		if err != nil {
+			output = result.Output
+		} else {
+			output = string(decoded)
+		}
+	}
+	if waitErr != nil {
+		return output, WrapErrorf(waitErr, "The build script of the image pipeline failed on %s, output: %s", instanceId, output)
+	}
+	return output, nil
+}
+
+// stopImagePipelineBuilder stops the builder instance so that the image is created from consistent disks.
+func stopImagePipelineBuilder(client *connectivity.ApsaraStackClient, instanceId string, timeout time.Duration) error {
+	ecsService := EcsService{client}
+	instance, err := ecsService.DescribeInstance(instanceId)
+	if err != nil {
+		return WrapError(err)
+	}
+	if instance.Status == string(Running) {
+		request := ecs.CreateStopInstanceRequest()
+		request.RegionId = client.RegionId
+		if strings.ToLower(client.Config.Protocol) == "https" {
+			request.Scheme = "https"
+		} else {
+			request.Scheme = "http"
+		}
+		request.Headers = map[string]string{"RegionId": client.RegionId}
+		request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+		request.InstanceId = instanceId
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.StopInstance(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, instanceId, request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	}
+
+	stateConf := BuildStateConf([]string{"Running", "Stopping"}, []string{"Stopped"}, timeout, 5*time.Second, ecsService.InstanceStateRefreshFunc(instanceId, []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, instanceId)
+	}
+	return nil
+}
+
+func deleteImagePipelineBuilder(client *connectivity.ApsaraStackClient, instanceId string, timeout time.Duration) error {
+	ecsService := EcsService{client}
+	request := ecs.CreateDeleteInstanceRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.InstanceId = instanceId
+	request.Force = requests.NewBoolean(true)
+
+	wait := incrementalWait(1*time.Second, 1*time.Second)
+	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DeleteInstance(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"IncorrectInstanceStatus", "IncorrectInstanceStatus.Initializing"}) {
+				return resource.RetryableError(err)
+			}
+			if IsExpectedErrors(err, []string{Throttling, "LastTokenProcessing"}) {
+				wait()
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		if IsExpectedErrors(err, EcsNotFound) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, instanceId, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{"Pending", "Running", "Stopped", "Stopping"}, []string{}, timeout, 10*time.Second, ecsService.InstanceStateRefreshFunc(instanceId, []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, instanceId)
+	}
+	return nil
+}
+
+// copyImagePipelineImage copies the baked image to every region of copy_to_region_ids.
+func copyImagePipelineImage(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+
+	copied := make([]map[string]interface{}, 0)
+	for _, v := range d.Get("copy_to_region_ids").([]interface{}) {
+		regionId := v.(string)
+		request := ecs.CreateCopyImageRequest()
+		if strings.ToLower(client.Config.Protocol) == "https" {
+			request.Scheme = "https"
+		} else {
+			request.Scheme = "http"
+		}
+		request.RegionId = client.RegionId
+		request.Headers = map[string]string{"RegionId": client.RegionId}
+		request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+		request.ImageId = d.Id()
+		request.DestinationRegionId = regionId
+		request.DestinationImageName = d.Get("image_name").(string)
+		request.DestinationDescription = d.Get("description").(string)
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.CopyImage(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*ecs.CopyImageResponse)
+		copied = append(copied, map[string]interface{}{
+			"region_id": regionId,
+			"image_id":  response.ImageId,
+		})
+		// Record the copies as soon as they exist so that they are released with the pipeline even if a later copy fails.
+		d.Set("copied_images", copied)
+
+		stateConf := BuildStateConf([]string{"Creating", "Waiting", ""}, []string{"Available"}, d.Timeout(schema.TimeoutCreate), 1*time.Minute,
+			ecsService.ImageStateRefreshFuncforcopy(response.ImageId, regionId, []string{"CreateFailed", "UnAvailable"}))
+		if _, err := stateConf.WaitForState(); err != nil {
+			return WrapErrorf(err, IdMsg, response.ImageId)
+		}
+	}
+	return nil
+}
+
+func modifyImagePipelineSharePermission(client *connectivity.ApsaraStackClient, imageId string, add, remove []string) error {
+	if len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+	request := ecs.CreateModifyImageSharePermissionRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.ImageId = imageId
+	if len(add) > 0 {
+		request.AddAccount = &add
+	}
+	if len(remove) > 0 {
+		request.RemoveAccount = &remove
+	}
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ModifyImageSharePermission(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, imageId, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	return nil
+}
+
+func describeImagePipelineShareAccounts(client *connectivity.ApsaraStackClient, imageId string) ([]string, error) {
+	request := ecs.CreateDescribeImageSharePermissionRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.ImageId = imageId
+	request.PageSize = requests.NewInteger(PageSizeXLarge)
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeImageSharePermission(request)
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, imageId, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	response, _ := raw.(*ecs.DescribeImageSharePermissionResponse)
+	accounts := make([]string, 0, len(response.Accounts.Account))
+	for _, account := range response.Accounts.Account {
+		accounts = append(accounts, account.AliyunId)
+	}
+	return accounts, nil
+}
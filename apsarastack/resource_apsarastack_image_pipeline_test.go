@@ -0,0 +1,108 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackImagePipelineBasic(t *testing.T) {
+	var v ecs.Image
+	resourceId := "apsarastack_image_pipeline.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"image_id":      CHECKSET,
+		"instance_type": CHECKSET,
+		"build_method":  "CloudAssistant",
+	})
+	serviceFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInitWithDescribeMethod(resourceId, &v, serviceFunc, "DescribeImageById")
+	rac := resourceAttrCheckInit(rc, ra)
+
+	rand := acctest.RandIntRange(1000, 9999)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	name := fmt.Sprintf("tf-testAccEcsImagePipeline%d", rand)
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceImagePipelineConfigDependence)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"image_id":          "${data.apsarastack_images.default.ids[0]}",
+					"instance_type":     "${data.apsarastack_instance_types.default.ids[0]}",
+					"vswitch_id":        "${apsarastack_vswitch.default.id}",
+					"security_group_id": "${apsarastack_security_group.default.id}",
+					"build_script":      "echo baked > /etc/tf-image-pipeline",
+					"image_name":        "${var.name}",
+					"description":       "${var.name}",
+					"tags": map[string]string{
+						"Created": "TF",
+					},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"image_name":            name,
+						"description":           name,
+						"tags.%":                "1",
+						"tags.Created":          "TF",
+						"disk_device_mapping.#": CHECKSET,
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"image_name":  "${var.name}_update",
+					"description": "${var.name}_update",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"image_name":  name + "_update",
+						"description": name + "_update",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func resourceImagePipelineConfigDependence(name string) string {
+	return fmt.Sprintf(`
+variable "name" {
+	default = "%s"
+}
+
+data "apsarastack_instance_types" "default" {
+ 	cpu_core_count    = 1
+	memory_size       = 2
+}
+
+data "apsarastack_images" "default" {
+  name_regex  = "^ubuntu_18.*64"
+  owners      = "system"
+}
+resource "apsarastack_vpc" "default" {
+  name       = "${var.name}"
+  cidr_block = "172.16.0.0/16"
+}
+resource "apsarastack_vswitch" "default" {
+  vpc_id            = "${apsarastack_vpc.default.id}"
+  cidr_block        = "172.16.0.0/24"
+  availability_zone = "${data.apsarastack_instance_types.default.instance_types.0.availability_zones.0}"
+  name              = "${var.name}"
+}
+resource "apsarastack_security_group" "default" {
+  name   = "${var.name}"
+  vpc_id = "${apsarastack_vpc.default.id}"
+}
+`, name)
+}
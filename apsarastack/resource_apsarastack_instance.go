@@ -1,10 +1,12 @@
 package apsarastack
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"log"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -29,6 +31,7 @@ func resourceApsaraStackInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceApsaraStackInstanceCustomizeDiff,
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
@@ -44,21 +47,32 @@ func resourceApsaraStackInstance() *schema.Resource {
 				Computed: true,
 			},
 
+			// The image and instance type can only be left out when they are taken from the launch template.
 			"image_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{"image_id", "launch_template_id"},
 			},
 
 			"instance_type": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{"instance_type", "launch_template_id"},
 				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^ecs\..*`), "prefix must be 'ecs.'"),
 			},
+			"dry_run_spec_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 
 			"security_groups": {
 				Type:     schema.TypeSet,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Optional: true,
+				Computed: true,
 			},
 
 			"instance_name": {
@@ -135,6 +149,14 @@ func resourceApsaraStackInstance() *schema.Resource {
 				Optional:     true,
 				ValidateFunc: validation.StringLenBetween(2, 256),
 			},
+			"system_disk_encrypted": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"system_disk_kms_key_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"data_disks": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -145,50 +167,46 @@ func resourceApsaraStackInstance() *schema.Resource {
 						"name": {
 							Type:         schema.TypeString,
 							Optional:     true,
-							ForceNew:     true,
 							ValidateFunc: validation.StringLenBetween(2, 128),
 						},
 						"size": {
 							Type:     schema.TypeInt,
 							Required: true,
-							ForceNew: true,
 						},
 						"category": {
 							Type:         schema.TypeString,
 							Optional:     true,
 							ValidateFunc: validation.StringInSlice([]string{"all", "cloud", "ephemeral_ssd", "cloud_efficiency", "cloud_ssd", "cloud_pperf", "cloud_sperf"}, false),
 							Default:      DiskCloudEfficiency,
-							ForceNew:     true,
 						},
 						"encrypted": {
 							Type:     schema.TypeBool,
 							Optional: true,
 							Default:  false,
-							ForceNew: true,
 						},
 						"kms_key_id": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 						},
 						"snapshot_id": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 						},
 
 						"delete_with_instance": {
 							Type:     schema.TypeBool,
 							Optional: true,
-							ForceNew: true,
 							Default:  true,
 						},
 						"description": {
 							Type:         schema.TypeString,
 							Optional:     true,
-							ForceNew:     true,
 							ValidateFunc: validation.StringLenBetween(2, 256),
 						},
+						"disk_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 					},
 				},
 			},
@@ -203,6 +221,7 @@ func resourceApsaraStackInstance() *schema.Resource {
 			"vswitch_id": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
 
 			"private_ip": {
@@ -223,8 +242,18 @@ func resourceApsaraStackInstance() *schema.Resource {
 			},
 
 			"user_data": {
-				Type:     schema.TypeString,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: userDataDiffSuppressFunc,
+			},
+			"user_data_reboot": {
+				Type:     schema.TypeBool,
 				Optional: true,
+				Default:  false,
+			},
+			"user_data_hash": {
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 			"role_name": {
 				Type:             schema.TypeString,
@@ -251,6 +280,19 @@ func resourceApsaraStackInstance() *schema.Resource {
 				}, false),
 			},
 
+			"launch_template_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"launch_template_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
 			"tags": tagsSchema(),
 		},
 	}
@@ -301,7 +343,7 @@ func resourceApsaraStackInstanceCreate(d *schema.ResourceData, meta interface{})
 	stateConf := BuildStateConf([]string{"Pending", "Starting", "Stopped"}, []string{"Running"}, d.Timeout(schema.TimeoutCreate), 120*time.Second, ecsService.InstanceStateRefreshFunc(d.Id(), []string{"Stopping"}))
 
 	if _, err := stateConf.WaitForState(); err != nil {
-		return WrapErrorf(err, IdMsg, d.Id())
+		return WrapErrorf(ecsService.WithInstanceConsoleOutput(err, d.Id()), IdMsg, d.Id())
 	}
 	if v, ok := d.GetOk("security_groups"); ok {
 		sgs := expandStringList(v.(*schema.Set).List())
@@ -341,6 +383,8 @@ func resourceApsaraStackInstanceRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("system_disk_size", disk.Size)
 	d.Set("system_disk_name", disk.DiskName)
 	d.Set("system_disk_description", disk.Description)
+	d.Set("system_disk_encrypted", disk.Encrypted)
+	d.Set("system_disk_kms_key_id", disk.KMSKeyId)
 	d.Set("instance_name", instance.InstanceName)
 	d.Set("description", instance.Description)
 	d.Set("status", instance.Status)
@@ -348,6 +392,7 @@ func resourceApsaraStackInstanceRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("host_name", instance.HostName)
 	d.Set("image_id", instance.ImageId)
 	d.Set("instance_type", instance.InstanceType)
+	d.Set("dry_run_spec_change", d.Get("dry_run_spec_change").(bool))
 	d.Set("password", d.Get("password").(string))
 	d.Set("internet_max_bandwidth_out", instance.InternetMaxBandwidthOut)
 	d.Set("internet_max_bandwidth_in", instance.InternetMaxBandwidthIn)
@@ -371,40 +416,43 @@ func resourceApsaraStackInstanceRead(d *schema.ResourceData, meta interface{}) e
 		return WrapError(err)
 	}
 
-	if !d.IsNewResource() || d.HasChange("user_data") {
-		dataRequest := ecs.CreateDescribeUserDataRequest()
-		if strings.ToLower(client.Config.Protocol) == "https" {
-			dataRequest.Scheme = "https"
-		} else {
-			dataRequest.Scheme = "http"
-		}
-		dataRequest.RegionId = client.RegionId
-		dataRequest.Headers = map[string]string{"RegionId": client.RegionId}
-		dataRequest.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
-		dataRequest.InstanceId = d.Id()
-		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
-			return ecsClient.DescribeUserData(dataRequest)
-		})
-
+	if v, ok := d.GetOk("data_disks"); ok {
+		disks, err := ecsService.DescribeDisksByType(d.Id(), DiskTypeData)
 		if err != nil {
-			return WrapErrorf(err, DefaultErrorMsg, d.Id(), dataRequest.GetActionName(), ApsaraStackSdkGoERROR)
+			return WrapError(err)
 		}
-		addDebug(dataRequest.GetActionName(), raw, dataRequest.RpcRequest, dataRequest)
-		response, _ := raw.(*ecs.DescribeUserDataResponse)
-		old_s := base64.StdEncoding.EncodeToString([]byte(response.UserData))
-
-		//log.Printf("User data : %s old data: %s", fmt.Sprint(d.Get("user_data").(string)),old_s)
-		d.Set("user_data", d.Get("user_data").(string))
-		//if d.Get("user_data").(string) == old_s {
-		//	d.Set("user_data", old_s)
-		//	log.Printf("set1")
-		//} else  {
-		//	d.Set("user_data", userDataHashSum(response.UserData))
-		//	log.Printf("set2")
-		//}
-		log.Printf("data : %s", old_s)
+		if err := d.Set("data_disks", refreshInstanceDataDisks(v.([]interface{}), disks)); err != nil {
+			return WrapError(err)
+		}
+	}
 
+	dataRequest := ecs.CreateDescribeUserDataRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		dataRequest.Scheme = "https"
+	} else {
+		dataRequest.Scheme = "http"
+	}
+	dataRequest.RegionId = client.RegionId
+	dataRequest.Headers = map[string]string{"RegionId": client.RegionId}
+	dataRequest.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	dataRequest.InstanceId = d.Id()
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeUserData(dataRequest)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), dataRequest.GetActionName(), ApsaraStackSdkGoERROR)
 	}
+	addDebug(dataRequest.GetActionName(), raw, dataRequest.RpcRequest, dataRequest)
+	userDataResponse, _ := raw.(*ecs.DescribeUserDataResponse)
+	// The user data is returned base64 encoded, and the decoded content is kept in the state to show drifts.
+	// It is only recorded once user_data is set, so user data coming from a launch template, an import or the
+	// console does not show up as a diff against a configuration without user_data.
+	userData := userDataHashSum(userDataResponse.UserData)
+	if d.Get("user_data").(string) != "" {
+		d.Set("user_data", userData)
+	}
+	d.Set("user_data_hash", fmt.Sprintf("%x", sha256.Sum256([]byte(userData))))
+	d.Set("user_data_reboot", d.Get("user_data_reboot").(bool))
 
 	if len(instance.VpcAttributes.VSwitchId) > 0 && (!d.IsNewResource() || d.HasChange("role_name")) {
 		request := ecs.CreateDescribeInstanceRamRoleRequest()
@@ -517,36 +565,8 @@ func resourceApsaraStackInstanceUpdate(d *schema.ResourceData, meta interface{})
 	}
 	if imageUpdate || vpcUpdate || passwordUpdate || typeUpdate {
 		run = true
-		instance, errDesc := ecsService.DescribeInstance(d.Id())
-		if errDesc != nil {
-			return WrapError(errDesc)
-		}
-		if instance.Status == string(Running) {
-			stopRequest := ecs.CreateStopInstanceRequest()
-			if strings.ToLower(client.Config.Protocol) == "https" {
-				stopRequest.Scheme = "https"
-			} else {
-				stopRequest.Scheme = "http"
-			}
-			stopRequest.RegionId = client.RegionId
-			stopRequest.Headers = map[string]string{"RegionId": client.RegionId}
-			stopRequest.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
-
-			stopRequest.InstanceId = d.Id()
-			stopRequest.ForceStop = requests.NewBoolean(false)
-			raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
-				return ecsClient.StopInstance(stopRequest)
-			})
-			if err != nil {
-				return WrapErrorf(err, DefaultErrorMsg, d.Id(), stopRequest.GetActionName(), ApsaraStackSdkGoERROR)
-			}
-			addDebug(stopRequest.GetActionName(), raw)
-		}
-
-		stateConf := BuildStateConf([]string{"Pending", "Running", "Stopping"}, []string{"Stopped"}, d.Timeout(schema.TimeoutUpdate), 5*time.Second, ecsService.InstanceStateRefreshFunc(d.Id(), []string{}))
-
-		if _, err = stateConf.WaitForState(); err != nil {
-			return WrapErrorf(err, IdMsg, d.Id())
+		if err := stopInstance(d, meta, false); err != nil {
+			return WrapError(err)
 		}
 
 		if _, err := modifyInstanceImage(d, meta, run); err != nil {
@@ -561,47 +581,27 @@ func resourceApsaraStackInstanceUpdate(d *schema.ResourceData, meta interface{})
 			return WrapError(err)
 		}
 
-		startRequest := ecs.CreateStartInstanceRequest()
-		if strings.ToLower(client.Config.Protocol) == "https" {
-			startRequest.Scheme = "https"
-		} else {
-			startRequest.Scheme = "http"
-		}
-		startRequest.Headers = map[string]string{"RegionId": client.RegionId}
-		startRequest.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
-		startRequest.InstanceId = d.Id()
-
-		err := resource.Retry(5*time.Minute, func() *resource.RetryError {
-			raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
-				return ecsClient.StartInstance(startRequest)
-			})
-			if err != nil {
-				if IsExpectedErrors(err, []string{"IncorrectInstanceStatus"}) {
-					time.Sleep(time.Second)
-					return resource.RetryableError(err)
-				}
-				return resource.NonRetryableError(err)
+		if err := startInstance(d, meta); err != nil {
+			if !typeUpdate {
+				return WrapError(err)
 			}
-			addDebug(startRequest.GetActionName(), raw)
-			return nil
-		})
-
-		if err != nil {
-			return WrapErrorf(err, DefaultErrorMsg, d.Id(), startRequest.GetActionName(), ApsaraStackSdkGoERROR)
-		}
-
-		// Start instance sometimes costs more than 8 minutes when os type is centos.
-		stateConf = &resource.StateChangeConf{
-			Pending:    []string{"Pending", "Starting", "Stopped"},
-			Target:     []string{"Running"},
-			Refresh:    ecsService.InstanceStateRefreshFunc(d.Id(), []string{}),
-			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      5 * time.Second,
-			MinTimeout: 3 * time.Second,
+			// The new instance type can not be started, restore the original one to keep the instance available.
+			// A PrePaid instance keeps the new type, since changing it back would place another paid order.
+			o, n := d.GetChange("instance_type")
+			instance, descErr := ecsService.DescribeInstance(d.Id())
+			if descErr != nil {
+				return WrapError(descErr)
+			}
+			if instance.InstanceChargeType == string(PrePaid) {
+				return WrapError(Error("Starting instance %s with type %s failed: %#v. The original type %s is not restored automatically for a PrePaid instance, its current type is %s.", d.Id(), n.(string), err, o.(string), instance.InstanceType))
+			}
+			if rollbackErr := rollbackInstanceType(d, meta, n.(string), o.(string)); rollbackErr != nil {
+				return WrapError(Error("Starting instance %s with type %s failed: %#v. Restoring the original type %s also failed: %#v", d.Id(), n.(string), err, o.(string), rollbackErr))
+			}
+			return WrapError(Error("Starting instance %s with type %s failed and the original type %s has been restored: %#v", d.Id(), n.(string), o.(string), err))
 		}
-
-		if _, err = stateConf.WaitForState(); err != nil {
-			return WrapErrorf(err, IdMsg, d.Id())
+		if typeUpdate {
+			d.SetPartial("instance_type")
 		}
 	}
 
@@ -609,10 +609,48 @@ func resourceApsaraStackInstanceUpdate(d *schema.ResourceData, meta interface{})
 		return WrapError(err)
 	}
 
+	if err := modifyInstanceDataDisks(d, meta); err != nil {
+		return WrapError(err)
+	}
+
 	d.Partial(false)
 	return resourceApsaraStackInstanceRead(d, meta)
 }
 
+// resourceApsaraStackInstanceCustomizeDiff rejects clearing the configured user data, replaces the instance when its data disks
+// can not be changed in place, and checks the new instance type has available stock at plan time when
+// dry_run_spec_change is set.
+func resourceApsaraStackInstanceCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChange("user_data") && d.NewValueKnown("user_data") && d.Get("user_data").(string) == "" {
+		return WrapError(Error("The user_data of instance %s can not be cleared once it is set. Set it to a new value instead, or taint the instance to replace it.", d.Id()))
+	}
+	if d.HasChange("data_disks") && d.NewValueKnown("data_disks") {
+		o, n := d.GetChange("data_disks")
+		if instanceDataDisksForceNew(o.([]interface{}), n.([]interface{})) {
+			if err := d.ForceNew("data_disks"); err != nil {
+				return WrapError(err)
+			}
+			return nil
+		}
+	}
+	if !d.Get("dry_run_spec_change").(bool) || !d.HasChange("instance_type") || !d.NewValueKnown("instance_type") {
+		return nil
+	}
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	instance, err := ecsService.DescribeInstance(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			return nil
+		}
+		return WrapError(err)
+	}
+	return ecsService.InstanceTypeAvailable(d.Get("instance_type").(string), instance.ZoneId, instance.InstanceChargeType)
+}
+
 func resourceApsaraStackInstanceDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.ApsaraStackClient)
 	ecsService := EcsService{client}
@@ -689,6 +727,13 @@ func buildApsaraStackInstanceArgs(d *schema.ResourceData, meta interface{}) (*ec
 	imageID := d.Get("image_id").(string)
 
 	request.ImageId = imageID
+	// The image and instance type can be taken from the launch template, and the other arguments override its values.
+	if v, ok := d.GetOk("launch_template_id"); ok {
+		request.LaunchTemplateId = v.(string)
+		if version, ok := d.GetOk("launch_template_version"); ok {
+			request.LaunchTemplateVersion = requests.NewInteger(version.(int))
+		}
+	}
 	if v := d.Get("system_disk_description").(string); v != "" {
 		request.SystemDiskDescription = v
 	}
@@ -700,6 +745,7 @@ func buildApsaraStackInstanceArgs(d *schema.ResourceData, meta interface{}) (*ec
 
 	request.SystemDiskCategory = string(systemDiskCategory)
 	request.SystemDiskSize = strconv.Itoa(d.Get("system_disk_size").(int))
+	setDefaultSystemDiskEncryption(client, request.QueryParams)
 
 	if v, ok := d.GetOk("security_groups"); ok {
 		// At present, the classic network instance does not support multi sg in runInstances
@@ -799,14 +845,23 @@ func buildApsaraStackInstanceArgs(d *schema.ResourceData, meta interface{}) (*ec
 					if j, ok := disk["kms_key_id"]; ok {
 						dataDiskRequest.KMSKeyId = j.(string)
 					}
+					if dataDiskRequest.KMSKeyId == "" {
+						dataDiskRequest.KMSKeyId = client.Config.DefaultDiskKMSKeyId
+					}
 					if dataDiskRequest.KMSKeyId == "" {
 						return nil, WrapError(errors.New("KmsKeyId can not be empty if encrypted is set to \"true\""))
 					}
 				}
 			}
-			if kms, ok := disk["kms_key_id"]; ok {
+			if kms, ok := disk["kms_key_id"]; ok && kms.(string) != "" {
 				dataDiskRequest.KMSKeyId = kms.(string)
 			}
+			if dataDiskRequest.Encrypted != "true" && client.Config.DefaultDiskKMSKeyId != "" && disk["snapshot_id"].(string) == "" {
+				dataDiskRequest.Encrypted = "true"
+				if dataDiskRequest.KMSKeyId == "" {
+					dataDiskRequest.KMSKeyId = client.Config.DefaultDiskKMSKeyId
+				}
+			}
 			if name, ok := disk["name"]; ok {
 				dataDiskRequest.DiskName = name.(string)
 			}
@@ -946,20 +1001,19 @@ func modifyInstanceAttribute(d *schema.ResourceData, meta interface{}) (bool, er
 
 	if d.HasChange("user_data") {
 		d.SetPartial("user_data")
-		old, new := d.GetChange("user_data")
-		old_s := base64.StdEncoding.EncodeToString([]byte(fmt.Sprint(old)))
-		if fmt.Sprint(new) != old_s {
-
-			if v, ok := d.GetOk("user_data"); ok && v.(string) != "" {
-				_, base64DecodeError := base64.StdEncoding.DecodeString(v.(string))
-				if base64DecodeError == nil {
-					request.UserData = v.(string)
-				} else {
-					request.UserData = base64.StdEncoding.EncodeToString([]byte(v.(string)))
-				}
+		// Clearing the user data is rejected at plan time, so only a new value is sent.
+		if v, ok := d.GetOk("user_data"); ok && v.(string) != "" {
+			_, base64DecodeError := base64.StdEncoding.DecodeString(v.(string))
+			if base64DecodeError == nil {
+				request.UserData = v.(string)
+			} else {
+				request.UserData = base64.StdEncoding.EncodeToString([]byte(v.(string)))
 			}
 			update = true
-			reboot = true
+			// The new user data only takes effect after the instance restarts.
+			if d.Get("user_data_reboot").(bool) {
+				reboot = true
+			}
 		}
 	}
 
@@ -1097,65 +1151,243 @@ func modifyInstanceType(d *schema.ResourceData, meta interface{}, run bool) (boo
 	update := false
 	if d.HasChange("instance_type") {
 		update = true
+		o, n := d.GetChange("instance_type")
+		instance, err := ecsService.DescribeInstance(d.Id())
+		if err != nil {
+			return update, WrapError(err)
+		}
 		if !run {
-			return update, nil
+			// Make sure the target type has stock before the instance is stopped.
+			return update, ecsService.InstanceTypeAvailable(n.(string), instance.ZoneId, instance.InstanceChargeType)
 		}
+		if err := modifyInstanceSpec(d, meta, instance.InstanceChargeType, o.(string), n.(string)); err != nil {
+			return update, WrapError(err)
+		}
+	}
+	return update, nil
+}
 
+// modifyInstanceSpec changes the type of a stopped instance from sourceType to targetType and waits until it takes effect.
+// PrePaid instances are changed by ModifyPrepayInstanceSpec, and PostPaid instances by ModifyInstanceSpec.
+func modifyInstanceSpec(d *schema.ResourceData, meta interface{}, chargeType, sourceType, targetType string) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+
+	var request *requests.RpcRequest
+	var invoker func(ecsClient *ecs.Client) (interface{}, error)
+	if chargeType == string(PrePaid) {
+		operatorType, err := instanceSpecOperatorType(ecsService, sourceType, targetType)
+		if err != nil {
+			return WrapError(err)
+		}
+		prepayRequest := ecs.CreateModifyPrepayInstanceSpecRequest()
+		if strings.ToLower(client.Config.Protocol) == "https" {
+			prepayRequest.Scheme = "https"
+		} else {
+			prepayRequest.Scheme = "http"
+		}
+		prepayRequest.Headers = map[string]string{"RegionId": client.RegionId}
+		prepayRequest.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+		prepayRequest.InstanceId = d.Id()
+		prepayRequest.InstanceType = targetType
+		prepayRequest.OperatorType = operatorType
+		prepayRequest.AutoPay = requests.NewBoolean(true)
+		prepayRequest.ClientToken = buildClientToken(prepayRequest.GetActionName())
+		request = prepayRequest.RpcRequest
+		invoker = func(ecsClient *ecs.Client) (interface{}, error) {
+			args := *prepayRequest
+			return ecsClient.ModifyPrepayInstanceSpec(&args)
+		}
+	} else {
 		//An instance that was successfully modified once cannot be modified again within 5 minutes.
-		request := ecs.CreateModifyInstanceSpecRequest()
-		request.InstanceId = d.Id()
+		specRequest := ecs.CreateModifyInstanceSpecRequest()
 		if strings.ToLower(client.Config.Protocol) == "https" {
-			request.Scheme = "https"
+			specRequest.Scheme = "https"
 		} else {
-			request.Scheme = "http"
+			specRequest.Scheme = "http"
 		}
-		request.Headers = map[string]string{"RegionId": client.RegionId}
-		request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
-		request.InstanceType = d.Get("instance_type").(string)
-		request.ClientToken = buildClientToken(request.GetActionName())
+		specRequest.Headers = map[string]string{"RegionId": client.RegionId}
+		specRequest.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+		specRequest.InstanceId = d.Id()
+		specRequest.InstanceType = targetType
+		specRequest.ClientToken = buildClientToken(specRequest.GetActionName())
+		request = specRequest.RpcRequest
+		invoker = func(ecsClient *ecs.Client) (interface{}, error) {
+			args := *specRequest
+			return ecsClient.ModifyInstanceSpec(&args)
+		}
+	}
 
-		err := resource.Retry(6*time.Minute, func() *resource.RetryError {
-			args := *request
-			raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
-				return ecsClient.ModifyInstanceSpec(&args)
-			})
-			if err != nil {
-				if IsExpectedErrors(err, []string{Throttling}) {
-					time.Sleep(10 * time.Second)
+	err := resource.Retry(6*time.Minute, func() *resource.RetryError {
+		raw, err := client.WithEcsClient(invoker)
+		if err != nil {
+			if IsExpectedErrors(err, []string{Throttling, "LastOrderProcessing", "LastRequestProcessing"}) {
+				time.Sleep(10 * time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	// Ensure instance's type has been replaced successfully.
+	timeout := DefaultTimeoutMedium
+	for {
+		instance, err := ecsService.DescribeInstance(d.Id())
+		if err != nil {
+			return WrapError(err)
+		}
+
+		if instance.InstanceType == targetType {
+			break
+		}
+
+		timeout = timeout - DefaultIntervalShort
+		if timeout <= 0 {
+			return WrapErrorf(err, WaitTimeoutMsg, d.Id(), GetFunc(1), timeout, instance.InstanceType, targetType, ProviderERROR)
+		}
+
+		time.Sleep(DefaultIntervalShort * time.Second)
+	}
+	return nil
+}
+
+// instanceSpecOperatorType works out whether changing sourceType to targetType is an upgrade or a downgrade.
+func instanceSpecOperatorType(ecsService EcsService, sourceType, targetType string) (string, error) {
+	source, err := ecsService.DescribeInstanceType(sourceType)
+	if err != nil {
+		return "", WrapError(err)
+	}
+	target, err := ecsService.DescribeInstanceType(targetType)
+	if err != nil {
+		return "", WrapError(err)
+	}
+	if target.CpuCoreCount < source.CpuCoreCount || (target.CpuCoreCount == source.CpuCoreCount && target.MemorySize < source.MemorySize) {
+		return "downgrade", nil
+	}
+	return "upgrade", nil
+}
+
+// rollbackInstanceType stops the PostPaid instance which failed to start with failedType, restores originalType and
+// starts it again.
+func rollbackInstanceType(d *schema.ResourceData, meta interface{}, failedType, originalType string) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	instance, err := ecsService.DescribeInstance(d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+	log.Printf("[WARN] Instance %s can not be started with type %s, restoring type %s.", d.Id(), failedType, originalType)
+	if err := stopInstance(d, meta, true); err != nil {
+		return WrapError(err)
+	}
+	if instance.InstanceType != originalType {
+		// The type of an instance can not be changed again for a few minutes after a change, so the rollback
+		// is retried until that is allowed.
+		err := resource.Retry(10*time.Minute, func() *resource.RetryError {
+			if err := modifyInstanceSpec(d, meta, instance.InstanceChargeType, instance.InstanceType, originalType); err != nil {
+				if IsExpectedErrors(err, []string{Throttling, ThrottlingUser, "Throttling.Resource"}) {
+					time.Sleep(30 * time.Second)
 					return resource.RetryableError(err)
 				}
 				return resource.NonRetryableError(err)
 			}
-			addDebug(request.GetActionName(), raw, request.RpcRequest, request)
 			return nil
 		})
 		if err != nil {
-			return update, WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+			return WrapError(err)
 		}
+	}
+	return startInstance(d, meta)
+}
 
-		// Ensure instance's type has been replaced successfully.
-		timeout := DefaultTimeoutMedium
-		for {
-			instance, err := ecsService.DescribeInstance(d.Id())
+func stopInstance(d *schema.ResourceData, meta interface{}, force bool) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	instance, errDesc := ecsService.DescribeInstance(d.Id())
+	if errDesc != nil {
+		return WrapError(errDesc)
+	}
+	if instance.Status == string(Running) || (force && instance.Status == string(Starting)) {
+		stopRequest := ecs.CreateStopInstanceRequest()
+		if strings.ToLower(client.Config.Protocol) == "https" {
+			stopRequest.Scheme = "https"
+		} else {
+			stopRequest.Scheme = "http"
+		}
+		stopRequest.RegionId = client.RegionId
+		stopRequest.Headers = map[string]string{"RegionId": client.RegionId}
+		stopRequest.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
 
-			if err != nil {
-				return update, WrapError(err)
-			}
+		stopRequest.InstanceId = d.Id()
+		stopRequest.ForceStop = requests.NewBoolean(force)
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.StopInstance(stopRequest)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), stopRequest.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(stopRequest.GetActionName(), raw)
+	}
 
-			if instance.InstanceType == d.Get("instance_type").(string) {
-				break
-			}
+	stateConf := BuildStateConf([]string{"Pending", "Running", "Starting", "Stopping"}, []string{"Stopped"}, d.Timeout(schema.TimeoutUpdate), 5*time.Second, ecsService.InstanceStateRefreshFunc(d.Id(), []string{}))
 
-			timeout = timeout - DefaultIntervalShort
-			if timeout <= 0 {
-				return update, WrapErrorf(err, WaitTimeoutMsg, d.Id(), GetFunc(1), timeout, instance.InstanceType, d.Get("instance_type"), ProviderERROR)
-			}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
 
-			time.Sleep(DefaultIntervalShort * time.Second)
+func startInstance(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	startRequest := ecs.CreateStartInstanceRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		startRequest.Scheme = "https"
+	} else {
+		startRequest.Scheme = "http"
+	}
+	startRequest.Headers = map[string]string{"RegionId": client.RegionId}
+	startRequest.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	startRequest.InstanceId = d.Id()
+
+	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.StartInstance(startRequest)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"IncorrectInstanceStatus"}) {
+				time.Sleep(time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
 		}
-		d.SetPartial("instance_type")
+		addDebug(startRequest.GetActionName(), raw)
+		return nil
+	})
+
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), startRequest.GetActionName(), ApsaraStackSdkGoERROR)
 	}
-	return update, nil
+
+	// Start instance sometimes costs more than 8 minutes when os type is centos.
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"Pending", "Starting", "Stopped"},
+		Target:     []string{"Running"},
+		Refresh:    ecsService.InstanceStateRefreshFunc(d.Id(), []string{}),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(ecsService.WithInstanceConsoleOutput(err, d.Id()), IdMsg, d.Id())
+	}
+	return nil
 }
 
 func modifyInstanceNetworkSpec(d *schema.ResourceData, meta interface{}) error {
@@ -1261,3 +1493,345 @@ func modifyInstanceNetworkSpec(d *schema.ResourceData, meta interface{}) error {
 	}
 	return nil
 }
+
+// pairInstanceDataDisks matches the planned data disks with the ones in state and returns the state index of
+// every planned disk which already exists. Disks are paired by name when it is set, and by their position otherwise,
+// so a removed disk without a name can not be told apart from the unnamed disks after it.
+func pairInstanceDataDisks(oldDisks, newDisks []interface{}) (matched map[int]int, removed []int) {
+	matched = make(map[int]int)
+	used := make(map[int]bool)
+	for i, n := range newDisks {
+		name := n.(map[string]interface{})["name"].(string)
+		if name == "" {
+			continue
+		}
+		for j, o := range oldDisks {
+			if !used[j] && o.(map[string]interface{})["name"].(string) == name {
+				matched[i] = j
+				used[j] = true
+				break
+			}
+		}
+	}
+	for i, n := range newDisks {
+		if n.(map[string]interface{})["name"].(string) != "" || i >= len(oldDisks) || used[i] {
+			continue
+		}
+		if oldDisks[i].(map[string]interface{})["name"].(string) == "" {
+			matched[i] = i
+			used[i] = true
+		}
+	}
+	for j := range oldDisks {
+		if !used[j] {
+			removed = append(removed, j)
+		}
+	}
+	return
+}
+
+// instanceDataDisksForceNew reports whether the data disk changes need a new instance. Disks can be added,
+// grown and have their description or delete_with_instance changed in place, and named disks which are not
+// deleted with the instance can be detached.
+func instanceDataDisksForceNew(oldDisks, newDisks []interface{}) bool {
+	matched, removed := pairInstanceDataDisks(oldDisks, newDisks)
+	for _, j := range removed {
+		oldDisk := oldDisks[j].(map[string]interface{})
+		if oldDisk["delete_with_instance"].(bool) || oldDisk["name"].(string) == "" {
+			return true
+		}
+	}
+	for i, j := range matched {
+		oldDisk, newDisk := oldDisks[j].(map[string]interface{}), newDisks[i].(map[string]interface{})
+		if newDisk["size"].(int) < oldDisk["size"].(int) {
+			return true
+		}
+		for _, key := range []string{"category", "encrypted", "kms_key_id", "snapshot_id"} {
+			if oldDisk[key] != newDisk[key] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// refreshInstanceDataDisks updates the data disks in state from the disks attached to the instance. Disks without
+// an ID yet are matched by name, and otherwise in the order of their device names.
+func refreshInstanceDataDisks(configured []interface{}, disks []ecs.Disk) []map[string]interface{} {
+	sort.SliceStable(disks, func(i, j int) bool {
+		return disks[i].Device < disks[j].Device
+	})
+	diskMap := make(map[string]ecs.Disk)
+	for _, disk := range disks {
+		diskMap[disk.DiskId] = disk
+	}
+	claimed := make(map[string]bool)
+	for _, v := range configured {
+		if id := v.(map[string]interface{})["disk_id"].(string); id != "" {
+			claimed[id] = true
+		}
+	}
+
+	var result []map[string]interface{}
+	for _, v := range configured {
+		item := make(map[string]interface{})
+		for key, value := range v.(map[string]interface{}) {
+			item[key] = value
+		}
+		id := item["disk_id"].(string)
+		if id == "" {
+			for _, disk := range disks {
+				if claimed[disk.DiskId] || (item["name"].(string) != "" && disk.DiskName != item["name"].(string)) {
+					continue
+				}
+				id = disk.DiskId
+				claimed[id] = true
+				break
+			}
+		}
+		disk, ok := diskMap[id]
+		if !ok {
+			// The disk has been detached or released out of band.
+			continue
+		}
+		item["disk_id"] = disk.DiskId
+		item["size"] = disk.Size
+		result = append(result, item)
+	}
+	return result
+}
+
+func modifyInstanceDataDisks(d *schema.ResourceData, meta interface{}) error {
+	if d.IsNewResource() || !d.HasChange("data_disks") {
+		return nil
+	}
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+
+	o, n := d.GetChange("data_disks")
+	oldDisks, newDisks := o.([]interface{}), n.([]interface{})
+	matched, removed := pairInstanceDataDisks(oldDisks, newDisks)
+
+	for _, j := range removed {
+		if err := detachInstanceDataDisk(d, meta, oldDisks[j].(map[string]interface{})["disk_id"].(string)); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	var instance ecs.Instance
+	dataDisks := make([]map[string]interface{}, 0, len(newDisks))
+	for i, v := range newDisks {
+		newDisk := make(map[string]interface{})
+		for key, value := range v.(map[string]interface{}) {
+			newDisk[key] = value
+		}
+
+		j, ok := matched[i]
+		if !ok {
+			if instance.InstanceId == "" {
+				object, err := ecsService.DescribeInstance(d.Id())
+				if err != nil {
+					return WrapError(err)
+				}
+				instance = object
+			}
+			diskId, err := attachNewInstanceDataDisk(d, meta, instance.ZoneId, newDisk)
+			if err != nil {
+				return WrapError(err)
+			}
+			newDisk["disk_id"] = diskId
+			dataDisks = append(dataDisks, newDisk)
+			continue
+		}
+
+		oldDisk := oldDisks[j].(map[string]interface{})
+		diskId := oldDisk["disk_id"].(string)
+		newDisk["disk_id"] = diskId
+		if newDisk["size"].(int) > oldDisk["size"].(int) {
+			if err := resizeInstanceDataDisk(d, meta, diskId, newDisk["size"].(int)); err != nil {
+				return WrapError(err)
+			}
+		}
+		if newDisk["description"] != oldDisk["description"] || newDisk["delete_with_instance"] != oldDisk["delete_with_instance"] {
+			request := ecs.CreateModifyDiskAttributeRequest()
+			if strings.ToLower(client.Config.Protocol) == "https" {
+				request.Scheme = "https"
+			} else {
+				request.Scheme = "http"
+			}
+			request.RegionId = client.RegionId
+			request.Headers = map[string]string{"RegionId": client.RegionId}
+			request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+			request.DiskId = diskId
+			request.Description = newDisk["description"].(string)
+			request.DeleteWithInstance = requests.NewBoolean(newDisk["delete_with_instance"].(bool))
+			raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+				return ecsClient.ModifyDiskAttribute(request)
+			})
+			if err != nil {
+				return WrapErrorf(err, DefaultErrorMsg, diskId, request.GetActionName(), ApsaraStackSdkGoERROR)
+			}
+			addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		}
+		dataDisks = append(dataDisks, newDisk)
+	}
+
+	if err := d.Set("data_disks", dataDisks); err != nil {
+		return WrapError(err)
+	}
+	d.SetPartial("data_disks")
+	return nil
+}
+
+func resizeInstanceDataDisk(d *schema.ResourceData, meta interface{}, diskId string, size int) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	request := ecs.CreateResizeDiskRequest()
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.DiskId = diskId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.NewSize = requests.NewInteger(size)
+	request.Type = string(DiskResizeTypeOnline)
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ResizeDisk(request)
+	})
+	if IsExpectedErrors(err, DiskNotSupportOnlineChangeErrors) {
+		request.Type = string(DiskResizeTypeOffline)
+		raw, err = client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ResizeDisk(request)
+		})
+	}
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	return nil
+}
+
+// attachNewInstanceDataDisk creates a data disk in the zone of the instance and attaches it to the instance.
+func attachNewInstanceDataDisk(d *schema.ResourceData, meta interface{}, zoneId string, disk map[string]interface{}) (string, error) {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+
+	request := ecs.CreateCreateDiskRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.ZoneId = zoneId
+	request.DiskCategory = disk["category"].(string)
+	request.Size = requests.NewInteger(disk["size"].(int))
+	request.SnapshotId = disk["snapshot_id"].(string)
+	request.DiskName = disk["name"].(string)
+	request.Description = disk["description"].(string)
+	if disk["encrypted"].(bool) {
+		request.Encrypted = requests.NewBoolean(true)
+		request.KMSKeyId = disk["kms_key_id"].(string)
+		if request.KMSKeyId == "" {
+			request.KMSKeyId = client.Config.DefaultDiskKMSKeyId
+		}
+		if request.KMSKeyId == "" {
+			return "", WrapError(errors.New("KmsKeyId can not be empty if encrypted is set to \"true\""))
+		}
+	} else if client.Config.DefaultDiskKMSKeyId != "" && request.SnapshotId == "" {
+		request.Encrypted = requests.NewBoolean(true)
+		request.KMSKeyId = disk["kms_key_id"].(string)
+		if request.KMSKeyId == "" {
+			request.KMSKeyId = client.Config.DefaultDiskKMSKeyId
+		}
+	}
+	request.ClientToken = buildClientToken(request.GetActionName())
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.CreateDisk(request)
+	})
+	if err != nil {
+		return "", WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	response, _ := raw.(*ecs.CreateDiskResponse)
+	diskId := response.DiskId
+	if err := ecsService.WaitForDisk(diskId, Available, DefaultTimeout); err != nil {
+		return diskId, WrapError(err)
+	}
+
+	attachRequest := ecs.CreateAttachDiskRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		attachRequest.Scheme = "https"
+	} else {
+		attachRequest.Scheme = "http"
+	}
+	attachRequest.RegionId = client.RegionId
+	attachRequest.Headers = map[string]string{"RegionId": client.RegionId}
+	attachRequest.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	attachRequest.InstanceId = d.Id()
+	attachRequest.DiskId = diskId
+	attachRequest.DeleteWithInstance = requests.NewBoolean(disk["delete_with_instance"].(bool))
+	err = resource.Retry(5*time.Minute, func() *resource.RetryError {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.AttachDisk(attachRequest)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, DiskInvalidOperation) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(attachRequest.GetActionName(), raw, attachRequest.RpcRequest, attachRequest)
+		return nil
+	})
+	if err != nil {
+		return diskId, WrapErrorf(err, DefaultErrorMsg, d.Id(), attachRequest.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	if err := ecsService.WaitForDiskAttachment(diskId+":"+d.Id(), DiskInUse, DefaultTimeout); err != nil {
+		return diskId, WrapError(err)
+	}
+	return diskId, nil
+}
+
+// detachInstanceDataDisk detaches a data disk from the instance and keeps it.
+func detachInstanceDataDisk(d *schema.ResourceData, meta interface{}, diskId string) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	request := ecs.CreateDetachDiskRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.InstanceId = d.Id()
+	request.DiskId = diskId
+
+	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DetachDisk(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, DiskInvalidOperation) {
+				time.Sleep(3 * time.Second)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		if IsExpectedErrors(err, []string{"InvalidDiskId.NotFound"}) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, diskId, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	return WrapError(ecsService.WaitForDiskAttachment(diskId+":"+d.Id(), Deleted, DefaultTimeout))
+}
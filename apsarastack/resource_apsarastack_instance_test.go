@@ -271,7 +271,21 @@ func TestAccApsaraStackInstanceVpc(t *testing.T) {
 				}),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheck(map[string]string{
-						"user_data": "I_am_user_data_update",
+						"user_data":      "I_am_user_data_update",
+						"user_data_hash": CHECKSET,
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"user_data":        "I_am_user_data_reboot",
+					"user_data_reboot": "true",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"user_data":        "I_am_user_data_reboot",
+						"user_data_reboot": "true",
+						"status":           "Running",
 					}),
 				),
 			},
@@ -463,6 +477,102 @@ func TestAccApsaraStackInstanceDataDisks(t *testing.T) {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"security_enhancement_strategy", "data_disks", "user_data"},
 			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"data_disks": []map[string]string{
+						{
+							"name":        "disk1",
+							"size":        "30",
+							"category":    "cloud_efficiency",
+							"description": "disk1",
+						},
+						{
+							"name":        "disk2",
+							"size":        "20",
+							"category":    "cloud_efficiency",
+							"description": "disk2",
+						},
+						{
+							"name":                 "disk3",
+							"size":                 "20",
+							"category":             "cloud_efficiency",
+							"description":          "disk3",
+							"delete_with_instance": "false",
+						},
+					},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"data_disks.#":                      "3",
+						"data_disks.0.size":                 "30",
+						"data_disks.0.disk_id":              CHECKSET,
+						"data_disks.2.name":                 "disk3",
+						"data_disks.2.delete_with_instance": "false",
+						"data_disks.2.disk_id":              CHECKSET,
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"data_disks": []map[string]string{
+						{
+							"name":        "disk1",
+							"size":        "30",
+							"category":    "cloud_efficiency",
+							"description": "disk1",
+						},
+						{
+							"name":                 "disk2",
+							"size":                 "20",
+							"category":             "cloud_efficiency",
+							"description":          "disk2",
+							"delete_with_instance": "false",
+						},
+						{
+							"name":                 "disk3",
+							"size":                 "20",
+							"category":             "cloud_efficiency",
+							"description":          "disk3",
+							"delete_with_instance": "false",
+						},
+					},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"data_disks.1.delete_with_instance": "false",
+					}),
+				),
+			},
+			{
+				// Removing the middle disk detaches it and keeps the disk after it.
+				Config: testAccConfig(map[string]interface{}{
+					"data_disks": []map[string]string{
+						{
+							"name":        "disk1",
+							"size":        "30",
+							"category":    "cloud_efficiency",
+							"description": "disk1",
+						},
+						{
+							"name":                 "disk3",
+							"size":                 "20",
+							"category":             "cloud_efficiency",
+							"description":          "disk3",
+							"delete_with_instance": "false",
+						},
+					},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"data_disks.#":                      "2",
+						"data_disks.1.name":                 "disk3",
+						"data_disks.1.description":          "disk3",
+						"data_disks.1.delete_with_instance": "false",
+						"data_disks.1.disk_id":              CHECKSET,
+						"data_disks.2.name":                 REMOVEKEY,
+					}),
+				),
+			},
 		},
 	})
 }
@@ -509,10 +619,13 @@ func TestAccApsaraStackInstanceTypeUpdate(t *testing.T) {
 
 			{
 				Config: testAccConfig(map[string]interface{}{
-					"instance_type": "${data.apsarastack_instance_types.new2.instance_types.0.id}",
+					"instance_type":       "${data.apsarastack_instance_types.new2.instance_types.0.id}",
+					"dry_run_spec_change": "true",
 				}),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheck(nil),
+					testAccCheck(map[string]string{
+						"dry_run_spec_change": "true",
+					}),
 				),
 			},
 		},
@@ -576,6 +689,79 @@ func TestAccApsaraStackInstanceMulti(t *testing.T) {
 	})
 }
 
+func TestAccApsaraStackInstanceLaunchTemplate(t *testing.T) {
+	var v ecs.Instance
+
+	resourceId := "apsarastack_instance.default"
+	ra := resourceAttrInit(resourceId, map[string]string{})
+	serviceFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+
+	rand := acctest.RandIntRange(1000, 9999)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	name := fmt.Sprintf("tf-testAcc%sEcsInstanceLaunchTemplate%d", defaultRegionToTest, rand)
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceInstanceLaunchTemplateConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"launch_template_id":      "${apsarastack_launch_template.default.id}",
+					"launch_template_version": "1",
+					"instance_name":           "${var.name}",
+					"system_disk_category":    "cloud_efficiency",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"instance_name":           name,
+						"image_id":                CHECKSET,
+						"instance_type":           CHECKSET,
+						"launch_template_id":      CHECKSET,
+						"launch_template_version": "1",
+						"security_groups.#":       "1",
+						"vswitch_id":              CHECKSET,
+						"user_data":               "",
+					}),
+				),
+			},
+			{
+				// The user data comes from the launch template and is not in the configuration.
+				Config: testAccConfig(map[string]interface{}{
+					"launch_template_id":      "${apsarastack_launch_template.default.id}",
+					"launch_template_version": "1",
+					"instance_name":           "${var.name}",
+					"system_disk_category":    "cloud_efficiency",
+				}),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func resourceInstanceLaunchTemplateConfigDependence(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "apsarastack_launch_template" "default" {
+  name              = "${var.name}"
+  image_id          = "${data.apsarastack_images.default.images.0.id}"
+  instance_type     = "${data.apsarastack_instance_types.default.instance_types.0.id}"
+  security_group_id = "${apsarastack_security_group.default.0.id}"
+  vswitch_id        = "${apsarastack_vswitch.default.id}"
+  userdata          = "SV9hbV91c2VyX2RhdGE="
+}
+`, resourceInstanceVpcConfigDependence(name))
+}
+
 func resourceInstanceVpcConfigDependence(name string) string {
 	return fmt.Sprintf(`
 data "apsarastack_instance_types" "default" {
@@ -1,10 +1,13 @@
 package apsarastack
 
 import (
+	"fmt"
 	"io/ioutil"
 	"strings"
 	"time"
 
+	"golang.org/x/crypto/ssh"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 
 	"os"
@@ -43,9 +46,11 @@ func resourceApsaraStackKeyPair() *schema.Resource {
 				ValidateFunc: validation.StringLenBetween(0, 100),
 			},
 			"public_key": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateKeyPairPublicKey,
+				DiffSuppressFunc: keyPairPublicKeyDiffSuppressFunc,
 				StateFunc: func(v interface{}) string {
 					switch v.(type) {
 					case string:
@@ -154,6 +159,13 @@ func resourceApsaraStackKeyPairRead(d *schema.ResourceData, meta interface{}) er
 	}
 	d.Set("key_name", keyPair.KeyPairName)
 	d.Set("finger_print", keyPair.KeyPairFingerPrint)
+	if v, ok := d.GetOk("public_key"); ok {
+		// The public key body can not be fetched from the API, so the configured one is
+		// only kept while its fingerprint still matches the remote key pair.
+		if fingerPrint, err := keyPairPublicKeyFingerPrint(v.(string)); err == nil && !keyPairFingerPrintEqual(fingerPrint, keyPair.KeyPairFingerPrint) {
+			d.Set("public_key", "")
+		}
+	}
 	tags := keyPair.Tags.Tag
 	if len(tags) > 0 {
 		err = d.Set("tags", ecsService.tagsToMap(tags))
@@ -194,3 +206,55 @@ func resourceApsaraStackKeyPairDelete(d *schema.ResourceData, meta interface{})
 	}
 	return WrapError(ecsService.WaitForKeyPair(d.Id(), Deleted, DefaultTimeoutMedium))
 }
+
+// keyPairPublicKeyFingerPrint returns the RFC 4716 MD5 fingerprint of an OpenSSH formatted public key,
+// which is the same format as the KeyPairFingerPrint returned by ECS.
+func keyPairPublicKeyFingerPrint(publicKey string) (string, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(publicKey)))
+	if err != nil {
+		return "", WrapError(err)
+	}
+	return ssh.FingerprintLegacyMD5(key), nil
+}
+
+func keyPairFingerPrintEqual(a, b string) bool {
+	return strings.EqualFold(strings.Replace(a, ":", "", -1), strings.Replace(b, ":", "", -1))
+}
+
+func validateKeyPairPublicKey(v interface{}, k string) (ws []string, errors []error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(v.(string))))
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a public key in OpenSSH format: %s", k, err))
+		return
+	}
+	switch key.Type() {
+	case ssh.KeyAlgoRSA, ssh.KeyAlgoED25519, ssh.KeyAlgoECDSA256, ssh.KeyAlgoECDSA384, ssh.KeyAlgoECDSA521:
+	default:
+		errors = append(errors, fmt.Errorf("%q has an unsupported key type %s, expected one of %s, %s, %s, %s, %s.", k, key.Type(),
+			ssh.KeyAlgoRSA, ssh.KeyAlgoED25519, ssh.KeyAlgoECDSA256, ssh.KeyAlgoECDSA384, ssh.KeyAlgoECDSA521))
+	}
+	return
+}
+
+// keyPairPublicKeyDiffSuppressFunc replaces the key pair only when the fingerprint of public_key changes,
+// so comment or whitespace changes are ignored and an imported key pair can adopt its public key.
+func keyPairPublicKeyDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	if new == "" {
+		return false
+	}
+	newFingerPrint, err := keyPairPublicKeyFingerPrint(new)
+	if err != nil {
+		return false
+	}
+	if old == "" {
+		if d.Id() == "" {
+			return false
+		}
+		return keyPairFingerPrintEqual(newFingerPrint, d.Get("finger_print").(string))
+	}
+	oldFingerPrint, err := keyPairPublicKeyFingerPrint(old)
+	if err != nil {
+		return false
+	}
+	return keyPairFingerPrintEqual(oldFingerPrint, newFingerPrint)
+}
@@ -2,6 +2,7 @@ package apsarastack
 
 import (
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
@@ -122,7 +123,27 @@ func resourceApsaraStackKeyPairAttachmentRead(d *schema.ResourceData, meta inter
 
 	d.Set("key_name", object.KeyPairName)
 	if ids, ok := d.GetOk("instance_ids"); ok {
-		d.Set("instance_ids", ids)
+		// Only keep the instances which are still bound to the key pair, so that an instance whose key pair
+		// was changed or detached out of band shows up as a diff and gets attached again.
+		instanceIds := ids.(*schema.Set).List()
+		attachedIds, _, err := ecsService.QueryInstancesWithKeyPair(convertListToJsonString(instanceIds), keyName)
+		if err != nil {
+			return WrapError(err)
+		}
+		attached := make(map[string]bool)
+		for _, id := range attachedIds {
+			attached[id] = true
+		}
+		var drifted []string
+		for _, id := range instanceIds {
+			if !attached[id.(string)] {
+				drifted = append(drifted, id.(string))
+			}
+		}
+		if len(drifted) > 0 {
+			log.Printf("[WARN] The instances %s are no longer attached to the key pair %s.", strings.Join(drifted, ","), keyName)
+		}
+		d.Set("instance_ids", attachedIds)
 	} else {
 		ids, _, err := ecsService.QueryInstancesWithKeyPair("", keyName)
 		if err != nil {
@@ -150,6 +171,20 @@ func resourceApsaraStackKeyPairAttachmentDelete(d *schema.ResourceData, meta int
 	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
 	request.KeyPairName = keyName
 
+	// The instances whose key pair has been changed out of band can not be detached any more.
+	attachedIds, _, err := ecsService.QueryInstancesWithKeyPair(instanceIds, keyName)
+	if err != nil {
+		return WrapError(err)
+	}
+	if len(attachedIds) < 1 {
+		return nil
+	}
+	var ids []interface{}
+	for _, id := range attachedIds {
+		ids = append(ids, id)
+	}
+	instanceIds = convertListToJsonString(ids)
+
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		request.InstanceIds = instanceIds
 		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
@@ -194,7 +194,7 @@ func TestAccApsaraStackKeyPairBasic(t *testing.T) {
 				Config: providerCommon + testAccKeyPairConfig_public_key(rand),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheck(map[string]string{
-						"public_key": "ssh-rsa AAAAB3Nza12345678qwertyuudsfsg",
+						"public_key": testAccKeyPairRSAPublicKey,
 					}),
 				),
 			},
@@ -212,6 +212,67 @@ func TestAccApsaraStackKeyPairBasic(t *testing.T) {
 					}),
 				),
 			},
+			{
+				ResourceName:            resourceId,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"public_key", "key_file"},
+			},
+		},
+	})
+
+}
+
+func TestAccApsaraStackKeyPairPublicKeyTypes(t *testing.T) {
+	var v ecs.KeyPair
+	resourceId := "apsarastack_key_pair.default"
+	ra := resourceAttrInit(resourceId, testAccCheckKeyPairBasicMap)
+	serviceFunc := func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	rand := acctest.RandInt()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKeyPairDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: providerCommon + testAccKeyPairConfigPublicKey(rand, testAccKeyPairRSAPublicKey),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"public_key": testAccKeyPairRSAPublicKey,
+					}),
+				),
+			},
+			{
+				// The comment does not change the fingerprint, so the key pair is kept.
+				Config:   providerCommon + testAccKeyPairConfigPublicKey(rand, testAccKeyPairRSAPublicKey+" tf-testAcc@example.com"),
+				PlanOnly: true,
+			},
+			{
+				Config: providerCommon + testAccKeyPairConfigPublicKey(rand, testAccKeyPairED25519PublicKey),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"public_key": testAccKeyPairED25519PublicKey,
+					}),
+				),
+			},
+			{
+				Config: providerCommon + testAccKeyPairConfigPublicKey(rand, testAccKeyPairECDSAPublicKey),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"public_key": testAccKeyPairECDSAPublicKey,
+					}),
+				),
+			},
 		},
 	})
 
@@ -254,6 +315,21 @@ var testAccCheckKeyPairBasicMap = map[string]string{
 	"key_name":     CHECKSET,
 }
 
+const testAccKeyPairRSAPublicKey = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCv/ZqLXWCsduDVN1b/JSCrLm1TXbVi6DBzNgDYRuQqZ9I6RzafGZt23GXKlIG6kDFjN8St3/rLMmSquFbOjn4tgFnWmQEvE+TMQfDwdjLV0UxABx2WjtpX627U7b5i7xM9s2g80zFBNPxfC0zlJKOx4fq3xubBF95wBIMSRHHLk6+oH/c6nsPfV83vougBKeCNFic3z5/Pexd3xI/DmH94OFtmlUmuqGgbT9JV+RFop3Nh8XhC9KjJzVrXSwYhss6RszrvDj2dtW/wcRcRtc/sM1YZXiQTB9ugWc0P2hQkaSgxjf88IJjur9x44lJWAd8zhwxdW1pp+zHjyn0TgmMJ"
+
+const testAccKeyPairED25519PublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIImmdX80moXOvQzIWiw5Pgdb7ydR7yfeArFIEqSvjimg"
+
+const testAccKeyPairECDSAPublicKey = "ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBN0M6xvjcnKrMvybkXbHHBprOxUjhOAvpIahdXVZ/w2AE1RYmr6TfK76Qv/fLPQfcm2b3qo7BL3uLxIRSzG0BHw="
+
+func testAccKeyPairConfigPublicKey(rand int, publicKey string) string {
+	return fmt.Sprintf(`
+resource "apsarastack_key_pair" "default" {
+	key_name   = "tf-testAccKeyPairConfig%d"
+	public_key = "%s"
+}
+`, rand, publicKey)
+}
+
 func testAccKeyPairConfigBasic(rand int) string {
 	return fmt.Sprintf(`
 resource "apsarastack_key_pair" "default" {
@@ -266,29 +342,29 @@ func testAccKeyPairConfig_public_key(rand int) string {
 	return fmt.Sprintf(`
 resource "apsarastack_key_pair" "default" {
 	key_name ="tf-testAccKeyPairConfig%d"
-	public_key = "ssh-rsa AAAAB3Nza12345678qwertyuudsfsg"
+	public_key = "%s"
     
 }
-`, rand)
+`, rand, testAccKeyPairRSAPublicKey)
 }
 func testAccKeyPairConfig_tag(rand int) string {
 	return fmt.Sprintf(`
 resource "apsarastack_key_pair" "default" {
 	key_name ="tf-testAccKeyPairConfig%d"
-	public_key = "ssh-rsa AAAAB3Nza12345678qwertyuudsfsg"
+	public_key = "%s"
     
 }
-`, rand)
+`, rand, testAccKeyPairRSAPublicKey)
 }
 
 func testAccKeyPairConfig_key_name(rand int) string {
 	return fmt.Sprintf(`
 resource "apsarastack_key_pair" "default" {
 	key_name  = "tf-testAccKeyPairConfig%d"
-	public_key = "ssh-rsa AAAAB3Nza12345678qwertyuudsfsg"
+	public_key = "%s"
     
 }
-`, rand)
+`, rand, testAccKeyPairRSAPublicKey)
 }
 
 func testAccKeyPairConfigMulti(rand int) string {
@@ -253,6 +253,7 @@ func resourceApsaraStackLaunchTemplate() *schema.Resource {
 						"encrypted": {
 							Type:     schema.TypeBool,
 							Optional: true,
+							Computed: true,
 						},
 						"size": {
 							Type:     schema.TypeInt,
@@ -265,6 +266,27 @@ func resourceApsaraStackLaunchTemplate() *schema.Resource {
 					},
 				},
 			},
+
+			"update_default_version": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"default_version_number": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"latest_version_number": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"version_number": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -307,6 +329,7 @@ func resourceApsaraStackLaunchTemplateCreate(d *schema.ResourceData, meta interf
 	request.SystemDiskCategory = d.Get("system_disk_category").(string)
 	request.SystemDiskDescription = d.Get("system_disk_description").(string)
 	request.SystemDiskSize = requests.NewInteger(d.Get("system_disk_size").(int))
+	setLaunchTemplateDefaultDiskEncryption(client, request.QueryParams, d.Get("data_disks").([]interface{}))
 	request.UserData = d.Get("userdata").(string)
 	request.VSwitchId = d.Get("vswitch_id").(string)
 	request.VpcId = d.Get("vpc_id").(string)
@@ -337,7 +360,7 @@ func resourceApsaraStackLaunchTemplateCreate(d *schema.ResourceData, meta interf
 				Size:               fmt.Sprintf("%d", diskRaw["size"].(int)),
 				SnapshotId:         diskRaw["snapshot_id"].(string),
 				Category:           diskRaw["category"].(string),
-				Encrypted:          fmt.Sprintf("%v", diskRaw["encrypted"].(bool)),
+				Encrypted:          fmt.Sprintf("%v", diskRaw["encrypted"].(bool) || (client.Config.DefaultDiskKMSKeyId != "" && diskRaw["snapshot_id"].(string) == "")),
 				DiskName:           diskRaw["name"].(string),
 				Description:        diskRaw["description"].(string),
 				DeleteWithInstance: fmt.Sprintf("%v", diskRaw["delete_with_instance"].(bool)),
@@ -367,6 +390,7 @@ func resourceApsaraStackLaunchTemplateCreate(d *schema.ResourceData, meta interf
 	response, _ := raw.(*ecs.CreateLaunchTemplateResponse)
 
 	d.SetId(response.LaunchTemplateId)
+	d.Set("version_number", 1)
 
 	return resourceApsaraStackLaunchTemplateRead(d, meta)
 }
@@ -382,7 +406,18 @@ func resourceApsaraStackLaunchTemplateRead(d *schema.ResourceData, meta interfac
 		}
 		return WrapError(err)
 	}
-	latestVersion, err := ecsService.DescribeLaunchTemplateVersion(d.Id(), int(object.LatestVersionNumber))
+	// The arguments are read from the version this resource created last, so versions created by
+	// apsarastack_launch_template_version do not show up as a drift. An import reads the default version.
+	versionNumber := d.Get("version_number").(int)
+	if versionNumber < 1 {
+		versionNumber = int(object.DefaultVersionNumber)
+	}
+	version, err := ecsService.DescribeLaunchTemplateVersion(d.Id(), versionNumber)
+	if err != nil && NotFoundError(err) && versionNumber != int(object.DefaultVersionNumber) {
+		// The version has been deleted out of band, so the default version is compared instead.
+		versionNumber = int(object.DefaultVersionNumber)
+		version, err = ecsService.DescribeLaunchTemplateVersion(d.Id(), versionNumber)
+	}
 	if err != nil {
 		if NotFoundError(err) {
 			d.SetId("")
@@ -391,38 +426,47 @@ func resourceApsaraStackLaunchTemplateRead(d *schema.ResourceData, meta interfac
 		return WrapError(err)
 	}
 
-	d.Set("name", latestVersion.LaunchTemplateName)
-	d.Set("description", latestVersion.LaunchTemplateData.Description)
-	d.Set("host_name", latestVersion.LaunchTemplateData.HostName)
-	d.Set("image_id", latestVersion.LaunchTemplateData.ImageId)
-	d.Set("image_owner_alias", latestVersion.LaunchTemplateData.ImageOwnerAlias)
-	d.Set("instance_charge_type", latestVersion.LaunchTemplateData.InstanceChargeType)
-	d.Set("instance_name", latestVersion.LaunchTemplateData.InstanceName)
-	d.Set("instance_type", latestVersion.LaunchTemplateData.InstanceType)
-	d.Set("auto_release_time", latestVersion.LaunchTemplateData.AutoReleaseTime)
-	d.Set("internet_charge_type", latestVersion.LaunchTemplateData.InternetChargeType)
-	d.Set("internet_max_bandwidth_in", latestVersion.LaunchTemplateData.InternetMaxBandwidthIn)
-	d.Set("internet_max_bandwidth_out", latestVersion.LaunchTemplateData.InternetMaxBandwidthOut)
-	d.Set("io_optimized", latestVersion.LaunchTemplateData.IoOptimized)
-	d.Set("key_pair_name", latestVersion.LaunchTemplateData.KeyPairName)
-	d.Set("network_type", latestVersion.LaunchTemplateData.NetworkType)
-	d.Set("ram_role_name", latestVersion.LaunchTemplateData.RamRoleName)
-	d.Set("resource_group_id", latestVersion.LaunchTemplateData.ResourceGroupId)
-	d.Set("security_enhancement_strategy", latestVersion.LaunchTemplateData.SecurityEnhancementStrategy)
-	d.Set("security_group_id", latestVersion.LaunchTemplateData.SecurityGroupId)
-	d.Set("spot_price_limit", latestVersion.LaunchTemplateData.SpotPriceLimit)
-	d.Set("spot_strategy", latestVersion.LaunchTemplateData.SpotStrategy)
-	d.Set("system_disk_name", latestVersion.LaunchTemplateData.SystemDiskDiskName)
-	d.Set("system_disk_category", latestVersion.LaunchTemplateData.SystemDiskCategory)
-	d.Set("system_disk_description", latestVersion.LaunchTemplateData.SystemDiskDescription)
-	d.Set("system_disk_size", latestVersion.LaunchTemplateData.SystemDiskSize)
-	d.Set("resource_group_id", latestVersion.LaunchTemplateData.ResourceGroupId)
-	d.Set("userdata", latestVersion.LaunchTemplateData.UserData)
-	d.Set("vswitch_id", latestVersion.LaunchTemplateData.VSwitchId)
-	d.Set("vpc_id", latestVersion.LaunchTemplateData.VpcId)
-	d.Set("zone_id", latestVersion.LaunchTemplateData.ZoneId)
+	d.Set("name", version.LaunchTemplateName)
+	d.Set("default_version_number", object.DefaultVersionNumber)
+	d.Set("latest_version_number", object.LatestVersionNumber)
+	d.Set("version_number", versionNumber)
+	d.Set("update_default_version", d.Get("update_default_version"))
+	return WrapError(setLaunchTemplateVersionData(d, version.LaunchTemplateData))
+}
+
+// setLaunchTemplateVersionData sets the launch template data of a version into the state.
+func setLaunchTemplateVersionData(d *schema.ResourceData, data ecs.LaunchTemplateData) error {
+	d.Set("description", data.Description)
+	d.Set("host_name", data.HostName)
+	d.Set("image_id", data.ImageId)
+	d.Set("image_owner_alias", data.ImageOwnerAlias)
+	d.Set("instance_charge_type", data.InstanceChargeType)
+	d.Set("instance_name", data.InstanceName)
+	d.Set("instance_type", data.InstanceType)
+	d.Set("auto_release_time", data.AutoReleaseTime)
+	d.Set("internet_charge_type", data.InternetChargeType)
+	d.Set("internet_max_bandwidth_in", data.InternetMaxBandwidthIn)
+	d.Set("internet_max_bandwidth_out", data.InternetMaxBandwidthOut)
+	d.Set("io_optimized", data.IoOptimized)
+	d.Set("key_pair_name", data.KeyPairName)
+	d.Set("network_type", data.NetworkType)
+	d.Set("ram_role_name", data.RamRoleName)
+	d.Set("resource_group_id", data.ResourceGroupId)
+	d.Set("security_enhancement_strategy", data.SecurityEnhancementStrategy)
+	d.Set("security_group_id", data.SecurityGroupId)
+	d.Set("spot_price_limit", data.SpotPriceLimit)
+	d.Set("spot_strategy", data.SpotStrategy)
+	d.Set("system_disk_name", data.SystemDiskDiskName)
+	d.Set("system_disk_category", data.SystemDiskCategory)
+	d.Set("system_disk_description", data.SystemDiskDescription)
+	d.Set("system_disk_size", data.SystemDiskSize)
+	d.Set("resource_group_id", data.ResourceGroupId)
+	d.Set("userdata", data.UserData)
+	d.Set("vswitch_id", data.VSwitchId)
+	d.Set("vpc_id", data.VpcId)
+	d.Set("zone_id", data.ZoneId)
 	var interfaces []map[string]interface{}
-	for _, net := range latestVersion.LaunchTemplateData.NetworkInterfaces.NetworkInterface {
+	for _, net := range data.NetworkInterfaces.NetworkInterface {
 		ds := make(map[string]interface{})
 		ds["vswitch_id"] = net.VSwitchId
 		ds["security_group_id"] = net.SecurityGroupId
@@ -436,7 +480,7 @@ func resourceApsaraStackLaunchTemplateRead(d *schema.ResourceData, meta interfac
 	}
 
 	var disks []map[string]interface{}
-	for _, disk := range latestVersion.LaunchTemplateData.DataDisks.DataDisk {
+	for _, disk := range data.DataDisks.DataDisk {
 		ds := make(map[string]interface{})
 		ds["size"] = disk.Size
 		ds["snapshot_id"] = disk.SnapshotId
@@ -452,7 +496,7 @@ func resourceApsaraStackLaunchTemplateRead(d *schema.ResourceData, meta interfac
 	}
 
 	tags := make(map[string]interface{})
-	for _, tag := range latestVersion.LaunchTemplateData.Tags.InstanceTag {
+	for _, tag := range data.Tags.InstanceTag {
 		tags[tag.Key] = tag.Value
 	}
 	d.Set("tags", tags)
@@ -461,26 +505,52 @@ func resourceApsaraStackLaunchTemplateRead(d *schema.ResourceData, meta interfac
 }
 
 func resourceApsaraStackLaunchTemplateUpdate(d *schema.ResourceData, meta interface{}) error {
-	versions, err := getLaunchTemplateVersions(d.Id(), meta)
-	if err != nil {
-		return WrapError(err)
-	}
-	// Remove one of the oldest and non-default version when the total number reach 30
-	if len(versions) > 29 {
-		var oldestVersion int64
-		for _, version := range versions {
-			if !version.DefaultVersion && (oldestVersion == 0 || version.VersionNumber < oldestVersion) {
-				oldestVersion = version.VersionNumber
-			}
-		}
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	d.Partial(true)
 
-		err = deleteLaunchTemplateVersion(d.Id(), int(oldestVersion), meta)
+	if launchTemplateDataHasChange(d) {
+		versions, err := getLaunchTemplateVersions(d.Id(), meta)
 		if err != nil {
 			return WrapError(err)
 		}
+		// Versions are never deleted implicitly, they may be managed by apsarastack_launch_template_version.
+		if len(versions) > 29 {
+			return WrapError(Error("Launch template %s has reached the limit of 30 versions. Delete one of its non-default versions before changing it.", d.Id()))
+		}
+		request := buildLaunchTemplateVersionRequest(d, meta, d.Id())
+		raw, err := client.WithEcsClient(func(client *ecs.Client) (interface{}, error) {
+			return client.CreateLaunchTemplateVersion(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*ecs.CreateLaunchTemplateVersionResponse)
+		d.Set("version_number", int(response.LaunchTemplateVersionNumber))
+		if d.Get("update_default_version").(bool) {
+			if err := ecsService.ModifyLaunchTemplateDefaultVersion(d.Id(), int(response.LaunchTemplateVersionNumber)); err != nil {
+				return WrapError(err)
+			}
+		}
 	}
-	return WrapError(createLaunchTemplateVersion(d, meta))
+	d.SetPartial("update_default_version")
+	d.Partial(false)
+	return resourceApsaraStackLaunchTemplateRead(d, meta)
+}
 
+// launchTemplateDataHasChange reports whether any argument stored in a launch template version has changed.
+func launchTemplateDataHasChange(d *schema.ResourceData) bool {
+	for key := range resourceApsaraStackLaunchTemplate().Schema {
+		switch key {
+		case "name", "update_default_version", "default_version_number", "latest_version_number", "version_number":
+			continue
+		}
+		if d.HasChange(key) {
+			return true
+		}
+	}
+	return false
 }
 
 func resourceApsaraStackLaunchTemplateDelete(d *schema.ResourceData, meta interface{}) error {
@@ -561,7 +631,8 @@ func deleteLaunchTemplateVersion(id string, version int, meta interface{}) error
 	return nil
 }
 
-func createLaunchTemplateVersion(d *schema.ResourceData, meta interface{}) error {
+// buildLaunchTemplateVersionRequest builds a request creating a new version of the launch template from the launch template data arguments.
+func buildLaunchTemplateVersionRequest(d *schema.ResourceData, meta interface{}, launchTemplateId string) *ecs.CreateLaunchTemplateVersionRequest {
 	client := meta.(*connectivity.ApsaraStackClient)
 	request := ecs.CreateCreateLaunchTemplateVersionRequest()
 	if strings.ToLower(client.Config.Protocol) == "https" {
@@ -572,7 +643,7 @@ func createLaunchTemplateVersion(d *schema.ResourceData, meta interface{}) error
 	request.RegionId = client.RegionId
 	request.Headers = map[string]string{"RegionId": client.RegionId}
 	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
-	request.LaunchTemplateId = d.Id()
+	request.LaunchTemplateId = launchTemplateId
 	request.Description = d.Get("description").(string)
 	request.HostName = d.Get("host_name").(string)
 	request.ImageId = d.Get("image_id").(string)
@@ -598,6 +669,7 @@ func createLaunchTemplateVersion(d *schema.ResourceData, meta interface{}) error
 	request.SystemDiskCategory = d.Get("system_disk_category").(string)
 	request.SystemDiskDescription = d.Get("system_disk_description").(string)
 	request.SystemDiskSize = requests.NewInteger(d.Get("system_disk_size").(int))
+	setLaunchTemplateDefaultDiskEncryption(client, request.QueryParams, d.Get("data_disks").([]interface{}))
 	request.UserData = d.Get("userdata").(string)
 	request.VSwitchId = d.Get("vswitch_id").(string)
 	request.VpcId = d.Get("vpc_id").(string)
@@ -628,7 +700,7 @@ func createLaunchTemplateVersion(d *schema.ResourceData, meta interface{}) error
 				Size:               fmt.Sprintf("%d", diskRaw["size"].(int)),
 				SnapshotId:         diskRaw["snapshot_id"].(string),
 				Category:           diskRaw["category"].(string),
-				Encrypted:          fmt.Sprintf("%v", diskRaw["encrypted"].(bool)),
+				Encrypted:          fmt.Sprintf("%v", diskRaw["encrypted"].(bool) || (client.Config.DefaultDiskKMSKeyId != "" && diskRaw["snapshot_id"].(string) == "")),
 				DiskName:           diskRaw["name"].(string),
 				Description:        diskRaw["description"].(string),
 				DeleteWithInstance: fmt.Sprintf("%v", diskRaw["delete_with_instance"].(bool)),
@@ -647,14 +719,19 @@ func createLaunchTemplateVersion(d *schema.ResourceData, meta interface{}) error
 		})
 	}
 	request.Tag = &tags
+	return request
+}
 
-	raw, err := client.WithEcsClient(func(client *ecs.Client) (interface{}, error) {
-		return client.CreateLaunchTemplateVersion(request)
-	})
-	if err != nil {
-		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+// setLaunchTemplateDefaultDiskEncryption adds the KMS key of the provider default_disk_encryption to the system disk
+// and the data disks which are not created from a snapshot.
+func setLaunchTemplateDefaultDiskEncryption(client *connectivity.ApsaraStackClient, queryParams map[string]string, disks []interface{}) {
+	if client.Config.DefaultDiskKMSKeyId == "" {
+		return
+	}
+	setDefaultSystemDiskEncryption(client, queryParams)
+	for i, raw := range disks {
+		if raw.(map[string]interface{})["snapshot_id"].(string) == "" {
+			setExtraQueryParams(queryParams, map[string]string{fmt.Sprintf("DataDisk.%d.KMSKeyId", i+1): client.Config.DefaultDiskKMSKeyId})
+		}
 	}
-	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
-	return nil
-
 }
@@ -162,6 +162,9 @@ func TestAccApsaraStackLaunchTemplateBasic(t *testing.T) {
 						//"ram_role_name":           name,
 						"system_disk_description": name,
 						"system_disk_name":        name,
+						"default_version_number":  "1",
+						"latest_version_number":   "1",
+						"update_default_version":  "false",
 					}),
 				),
 			},
@@ -170,6 +173,39 @@ func TestAccApsaraStackLaunchTemplateBasic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"update_default_version": "true",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"update_default_version": "true",
+						"latest_version_number":  "1",
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"io_optimized": "optimized",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"io_optimized":           "optimized",
+						"default_version_number": "2",
+						"latest_version_number":  "2",
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"update_default_version": "false",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"update_default_version": "false",
+					}),
+				),
+			},
 			{
 				Config: testAccConfig(map[string]interface{}{
 					"io_optimized": "none",
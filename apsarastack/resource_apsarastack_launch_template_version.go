@@ -0,0 +1,138 @@
+package apsarastack
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceApsaraStackLaunchTemplateVersion() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackLaunchTemplateVersionCreate,
+		Read:   resourceApsaraStackLaunchTemplateVersionRead,
+		Update: resourceApsaraStackLaunchTemplateVersionUpdate,
+		Delete: resourceApsaraStackLaunchTemplateVersionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: launchTemplateVersionSchema(),
+	}
+}
+
+// launchTemplateVersionSchema reuses the launch template data arguments of apsarastack_launch_template.
+// A version can not be modified once it is created, so all of them force a new version.
+func launchTemplateVersionSchema() map[string]*schema.Schema {
+	versionSchema := make(map[string]*schema.Schema)
+	for key, value := range resourceApsaraStackLaunchTemplate().Schema {
+		switch key {
+		case "name", "update_default_version", "default_version_number", "latest_version_number":
+			continue
+		}
+		value.ForceNew = true
+		versionSchema[key] = value
+	}
+	versionSchema["launch_template_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+	versionSchema["default_version"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	versionSchema["version_number"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Computed: true,
+	}
+	versionSchema["launch_template_name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	return versionSchema
+}
+
+func resourceApsaraStackLaunchTemplateVersionCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	launchTemplateId := d.Get("launch_template_id").(string)
+
+	request := buildLaunchTemplateVersionRequest(d, meta, launchTemplateId)
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.CreateLaunchTemplateVersion(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "apsarastack_launch_template_version", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	response, _ := raw.(*ecs.CreateLaunchTemplateVersionResponse)
+	d.SetId(fmt.Sprintf("%s%s%d", launchTemplateId, COLON_SEPARATED, response.LaunchTemplateVersionNumber))
+
+	if d.Get("default_version").(bool) {
+		if err := ecsService.ModifyLaunchTemplateDefaultVersion(launchTemplateId, int(response.LaunchTemplateVersionNumber)); err != nil {
+			return WrapError(err)
+		}
+	}
+	return resourceApsaraStackLaunchTemplateVersionRead(d, meta)
+}
+
+func resourceApsaraStackLaunchTemplateVersionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	object, err := ecsService.DescribeLaunchTemplateVersionById(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("launch_template_id", object.LaunchTemplateId)
+	d.Set("launch_template_name", object.LaunchTemplateName)
+	d.Set("version_number", object.VersionNumber)
+	d.Set("default_version", object.DefaultVersion)
+	return WrapError(setLaunchTemplateVersionData(d, object.LaunchTemplateData))
+}
+
+func resourceApsaraStackLaunchTemplateVersionUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+
+	if d.HasChange("default_version") {
+		if !d.Get("default_version").(bool) {
+			return WrapError(Error("The launch template version %s can not stop being the default version by itself, please set another version as the default version instead.", d.Id()))
+		}
+		if err := ecsService.ModifyLaunchTemplateDefaultVersion(d.Get("launch_template_id").(string), d.Get("version_number").(int)); err != nil {
+			return WrapError(err)
+		}
+	}
+	return resourceApsaraStackLaunchTemplateVersionRead(d, meta)
+}
+
+func resourceApsaraStackLaunchTemplateVersionDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	object, err := ecsService.DescribeLaunchTemplateVersionById(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			return nil
+		}
+		return WrapError(err)
+	}
+	// The default version can not be deleted, it is removed together with its launch template.
+	if object.DefaultVersion {
+		log.Printf("[WARN] Launch template version %s is the default version and can not be deleted, it will be removed with its launch template.", d.Id())
+		return nil
+	}
+
+	err = deleteLaunchTemplateVersion(object.LaunchTemplateId, int(object.VersionNumber), meta)
+	if err != nil && !IsExpectedErrors(err, []string{"InvalidLaunchTemplate.NotFound", "InvalidLaunchTemplateVersion.NotFound"}) {
+		return WrapError(err)
+	}
+	return nil
+}
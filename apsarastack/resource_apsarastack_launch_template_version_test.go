@@ -0,0 +1,94 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackLaunchTemplateVersionBasic(t *testing.T) {
+	var v ecs.LaunchTemplateVersionSet
+
+	resourceId := "apsarastack_launch_template_version.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"launch_template_id": CHECKSET,
+		"version_number":     "2",
+	})
+	rc := resourceCheckInitWithDescribeMethod(resourceId, &v, func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}, "DescribeLaunchTemplateVersionById")
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	rand := acctest.RandInt()
+	name := fmt.Sprintf("tf-testaccLaunchTemplateVersion%v", rand)
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, resourceLaunchTemplateVersionConfigDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"launch_template_id": "${apsarastack_launch_template.default.id}",
+					"description":        name,
+					"image_id":           "${data.apsarastack_images.default.images.0.id}",
+					"instance_type":      "${data.apsarastack_instance_types.default.instance_types.0.id}",
+					"instance_name":      name,
+					"security_group_id":  "${apsarastack_security_group.default.id}",
+					"vswitch_id":         "${apsarastack_vswitch.default.id}",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"description":          name,
+						"instance_name":        name,
+						"image_id":             CHECKSET,
+						"instance_type":        CHECKSET,
+						"default_version":      "false",
+						"launch_template_name": name,
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"default_version": "true",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"default_version": "true",
+					}),
+					resource.TestCheckResourceAttr("apsarastack_launch_template.default", "version_number", "1"),
+					resource.TestCheckResourceAttr("apsarastack_launch_template.default", "default_version_number", "2"),
+				),
+			},
+			{
+				// The version created here must not make the launch template drift.
+				Config:   testAccConfig(map[string]interface{}{}),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func resourceLaunchTemplateVersionConfigDependence(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "apsarastack_launch_template" "default" {
+  name          = "${var.name}"
+  image_id      = "${data.apsarastack_images.default.images.0.id}"
+  instance_type = "${data.apsarastack_instance_types.default.instance_types.0.id}"
+}
+`, resourceLaunchTemplateConfigDependence(name))
+}
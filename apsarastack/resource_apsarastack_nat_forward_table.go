@@ -0,0 +1,373 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// natTableBatchSize is the number of entries created or deleted before waiting for them to settle.
+const natTableBatchSize = 20
+
+func resourceApsaraStackNatForwardTable() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackNatForwardTableCreate,
+		Read:   resourceApsaraStackNatForwardTableRead,
+		Update: resourceApsaraStackNatForwardTableUpdate,
+		Delete: resourceApsaraStackNatForwardTableDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"forward_table_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"entries": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"external_ip": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"external_port": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateForwardPort,
+						},
+						"ip_protocol": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"tcp", "udp", "any"}, false),
+						},
+						"internal_ip": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"internal_port": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateForwardPort,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"forward_entry_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Set: natForwardTableEntryHash,
+			},
+		},
+	}
+}
+
+func resourceApsaraStackNatForwardTableCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	tableId := d.Get("forward_table_id").(string)
+
+	// Entries that already exist in the table are adopted, and the ones missing from the configuration removed.
+	entries, err := vpcService.DescribeNatForwardTable(tableId)
+	if err != nil {
+		return WrapError(err)
+	}
+	d.SetId(tableId)
+
+	current := schema.NewSet(natForwardTableEntryHash, flattenNatForwardTableEntries(entries))
+	if err := updateNatForwardTableEntries(d, meta, current, d.Get("entries").(*schema.Set), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return WrapError(err)
+	}
+
+	return resourceApsaraStackNatForwardTableRead(d, meta)
+}
+
+func resourceApsaraStackNatForwardTableRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	entries, err := vpcService.DescribeNatForwardTable(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("forward_table_id", d.Id())
+	// Every entry in the table is recorded, so entries added out of band show up as a diff.
+	if err := d.Set("entries", flattenNatForwardTableEntries(entries)); err != nil {
+		return WrapError(err)
+	}
+	return nil
+}
+
+func resourceApsaraStackNatForwardTableUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("entries") {
+		o, n := d.GetChange("entries")
+		d.Partial(true)
+		if err := updateNatForwardTableEntries(d, meta, o.(*schema.Set), n.(*schema.Set), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return WrapError(err)
+		}
+		d.SetPartial("entries")
+		d.Partial(false)
+	}
+	return resourceApsaraStackNatForwardTableRead(d, meta)
+}
+
+func resourceApsaraStackNatForwardTableDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	entries, err := vpcService.DescribeNatForwardTable(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	current := schema.NewSet(natForwardTableEntryHash, flattenNatForwardTableEntries(entries))
+	empty := schema.NewSet(natForwardTableEntryHash, nil)
+	return WrapError(updateNatForwardTableEntries(d, meta, current, empty, d.Timeout(schema.TimeoutDelete)))
+}
+
+// updateNatForwardTableEntries deletes the entries only in oldEntries, creates the ones only in newEntries and
+// renames the ones in both whose name changed, waiting for each batch of natTableBatchSize entries to settle
+// before sending the next.
+func updateNatForwardTableEntries(d *schema.ResourceData, meta interface{}, oldEntries, newEntries *schema.Set, timeout time.Duration) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+
+	remove := oldEntries.Difference(newEntries).List()
+	for start := 0; start < len(remove); start += natTableBatchSize {
+		end := start + natTableBatchSize
+		if end > len(remove) {
+			end = len(remove)
+		}
+		var entryIds []string
+		for _, e := range remove[start:end] {
+			entryId := e.(map[string]interface{})["forward_entry_id"].(string)
+			if entryId == "" {
+				continue
+			}
+			if err := deleteNatForwardTableEntry(client, d.Id(), entryId, timeout); err != nil {
+				return WrapError(err)
+			}
+			entryIds = append(entryIds, entryId)
+		}
+		if len(entryIds) < 1 {
+			continue
+		}
+		stateConf := BuildStateConf([]string{"Pending", string(Available)}, []string{string(Deleted)}, timeout, 3*time.Second, vpcService.NatForwardTableEntriesStateRefreshFunc(d.Id(), entryIds))
+		if _, err := stateConf.WaitForState(); err != nil {
+			return WrapErrorf(err, IdMsg, d.Id())
+		}
+	}
+
+	add := newEntries.Difference(oldEntries).List()
+	for start := 0; start < len(add); start += natTableBatchSize {
+		end := start + natTableBatchSize
+		if end > len(add) {
+			end = len(add)
+		}
+		var entryIds []string
+		for _, e := range add[start:end] {
+			entryId, err := createNatForwardTableEntry(client, d.Id(), e.(map[string]interface{}), timeout)
+			if err != nil {
+				return WrapError(err)
+			}
+			entryIds = append(entryIds, entryId)
+		}
+		stateConf := BuildStateConf([]string{"Pending", string(Deleted)}, []string{string(Available)}, timeout, 3*time.Second, vpcService.NatForwardTableEntriesStateRefreshFunc(d.Id(), entryIds))
+		if _, err := stateConf.WaitForState(); err != nil {
+			return WrapErrorf(err, IdMsg, d.Id())
+		}
+	}
+
+	oldByHash := make(map[int]map[string]interface{})
+	for _, e := range oldEntries.List() {
+		oldByHash[natForwardTableEntryHash(e)] = e.(map[string]interface{})
+	}
+	var rename []interface{}
+	for _, e := range newEntries.List() {
+		old, ok := oldByHash[natForwardTableEntryHash(e)]
+		name := e.(map[string]interface{})["name"].(string)
+		if ok && old["forward_entry_id"].(string) != "" && name != "" && name != old["name"].(string) {
+			rename = append(rename, map[string]interface{}{"forward_entry_id": old["forward_entry_id"], "name": name})
+		}
+	}
+	for start := 0; start < len(rename); start += natTableBatchSize {
+		end := start + natTableBatchSize
+		if end > len(rename) {
+			end = len(rename)
+		}
+		var entryIds []string
+		for _, e := range rename[start:end] {
+			entry := e.(map[string]interface{})
+			entryId := entry["forward_entry_id"].(string)
+			if err := renameNatForwardTableEntry(client, d.Id(), entryId, entry["name"].(string), timeout); err != nil {
+				return WrapError(err)
+			}
+			entryIds = append(entryIds, entryId)
+		}
+		stateConf := BuildStateConf([]string{"Pending"}, []string{string(Available)}, timeout, 3*time.Second, vpcService.NatForwardTableEntriesStateRefreshFunc(d.Id(), entryIds))
+		if _, err := stateConf.WaitForState(); err != nil {
+			return WrapErrorf(err, IdMsg, d.Id())
+		}
+	}
+	return nil
+}
+
+func createNatForwardTableEntry(client *connectivity.ApsaraStackClient, tableId string, entry map[string]interface{}, timeout time.Duration) (string, error) {
+	request := vpc.CreateCreateForwardEntryRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.ForwardTableId = tableId
+	request.ExternalIp = entry["external_ip"].(string)
+	request.ExternalPort = entry["external_port"].(string)
+	request.IpProtocol = entry["ip_protocol"].(string)
+	request.InternalIp = entry["internal_ip"].(string)
+	request.InternalPort = entry["internal_port"].(string)
+	if v, ok := entry["name"].(string); ok && v != "" {
+		request.ForwardEntryName = v
+	}
+
+	var response *vpc.CreateForwardEntryResponse
+	wait := incrementalWait(1*time.Second, 1*time.Second)
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.CreateForwardEntry(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"InvalidIp.NotInNatgw", "TaskConflict", "OperationConflict", Throttling}) {
+				wait()
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ = raw.(*vpc.CreateForwardEntryResponse)
+		return nil
+	})
+	if err != nil {
+		return "", WrapErrorf(err, DefaultErrorMsg, tableId, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	return response.ForwardEntryId, nil
+}
+
+func renameNatForwardTableEntry(client *connectivity.ApsaraStackClient, tableId, entryId, name string, timeout time.Duration) error {
+	request := vpc.CreateModifyForwardEntryRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.ForwardTableId = tableId
+	request.ForwardEntryId = entryId
+	request.ForwardEntryName = name
+
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.ModifyForwardEntry(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"TaskConflict", "OperationConflict", Throttling}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, tableId+COLON_SEPARATED+entryId, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	return nil
+}
+
+func deleteNatForwardTableEntry(client *connectivity.ApsaraStackClient, tableId, entryId string, timeout time.Duration) error {
+	request := vpc.CreateDeleteForwardEntryRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.ForwardTableId = tableId
+	request.ForwardEntryId = entryId
+
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeleteForwardEntry(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"UnknownError", "TaskConflict", "OperationConflict", Throttling}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		if IsExpectedErrors(err, []string{"InvalidForwardEntryId.NotFound", "InvalidForwardTableId.NotFound"}) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, tableId+COLON_SEPARATED+entryId, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	return nil
+}
+
+func flattenNatForwardTableEntries(entries []vpc.ForwardTableEntry) []interface{} {
+	var result []interface{}
+	for _, entry := range entries {
+		result = append(result, map[string]interface{}{
+			"external_ip":      entry.ExternalIp,
+			"external_port":    entry.ExternalPort,
+			"ip_protocol":      entry.IpProtocol,
+			"internal_ip":      entry.InternalIp,
+			"internal_port":    entry.InternalPort,
+			"name":             entry.ForwardEntryName,
+			"forward_entry_id": entry.ForwardEntryId,
+		})
+	}
+	return result
+}
+
+// natForwardTableEntryHash leaves out forward_entry_id so that configured entries match the ones read back, and
+// the name so that renaming an entry modifies it instead of replacing it.
+func natForwardTableEntryHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	return hashcode.String(fmt.Sprintf("%s|%s|%s|%s|%s", m["external_ip"], m["external_port"], m["ip_protocol"], m["internal_ip"], m["internal_port"]))
+}
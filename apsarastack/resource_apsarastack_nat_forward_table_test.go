@@ -0,0 +1,132 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccApsaraStackNatForwardTableBasic(t *testing.T) {
+	resourceId := "apsarastack_nat_forward_table.default"
+	entryIds := make(map[string]bool)
+	rand := acctest.RandInt()
+	name := fmt.Sprintf("tf-testAccForwardEntryConfig%d", rand)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckNatForwardTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				// More entries than natTableBatchSize are created in two batches.
+				Config: testAccNatForwardTableConfig(rand, 25, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "entries.#", "25"),
+					testAccCheckNatForwardTableEntries(resourceId, name, entryIds),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccNatForwardTableConfig(rand, 25, name+"_update"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "entries.#", "25"),
+					testAccCheckNatForwardTableEntries(resourceId, name+"_update", entryIds),
+				),
+			},
+			{
+				Config: testAccNatForwardTableConfig(rand, 1, name+"_update"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "entries.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckNatForwardTableEntries checks that every entry has the given name. The entry ids are recorded
+// into entryIds on the first call, and later calls check that the entries were modified rather than replaced.
+func testAccCheckNatForwardTableEntries(resourceId, name string, entryIds map[string]bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceId]
+		if !ok {
+			return WrapError(fmt.Errorf("Not found: %s", resourceId))
+		}
+		record := len(entryIds) == 0
+		for key, value := range rs.Primary.Attributes {
+			switch {
+			case strings.HasSuffix(key, ".name") && value != name:
+				return WrapError(fmt.Errorf("%s: expected %s, got %s", key, name, value))
+			case strings.HasSuffix(key, ".forward_entry_id") && record:
+				entryIds[value] = true
+			case strings.HasSuffix(key, ".forward_entry_id") && !entryIds[value]:
+				return WrapError(fmt.Errorf("%s: forward entry %s was recreated", key, value))
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckNatForwardTableDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "apsarastack_nat_forward_table" {
+			continue
+		}
+		entries, err := vpcService.DescribeNatForwardTable(rs.Primary.ID)
+		if err != nil {
+			if NotFoundError(err) {
+				continue
+			}
+			return WrapError(err)
+		}
+		if len(entries) > 0 {
+			return WrapError(fmt.Errorf("Forward table %s still has %d entries", rs.Primary.ID, len(entries)))
+		}
+	}
+	return nil
+}
+
+func testAccNatForwardTableConfig(rand, count int, name string) string {
+	return fmt.Sprintf(`
+%s
+
+locals {
+  entries = [for i in range(%d) : {
+    external_port = 80 + i
+    internal_port = 8080 + i
+  }]
+}
+
+resource "apsarastack_nat_forward_table" "default" {
+  forward_table_id = "${apsarastack_nat_gateway.default.forward_table_ids}"
+
+  dynamic "entries" {
+    for_each = local.entries
+    content {
+      name          = "%s"
+      external_ip   = "${apsarastack_eip.default.0.ip_address}"
+      external_port = entries.value.external_port
+      ip_protocol   = "tcp"
+      internal_ip   = "172.16.0.4"
+      internal_port = entries.value.internal_port
+    }
+  }
+
+  depends_on = ["apsarastack_eip_association.default"]
+}
+`, testAccForwardEntryConfigCommon(rand), count, name)
+}
@@ -87,6 +87,12 @@ func resourceApsaraStackNatGateway() *schema.Resource {
 				MaxItems: 4,
 				Optional: true,
 			},
+			"tags": tagsSchema(),
+			// CreateNatGateway does not take a resource group, so it is inherited from the VPC.
+			"resource_group_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -151,6 +157,9 @@ func resourceApsaraStackNatGatewayCreate(d *schema.ResourceData, meta interface{
 	if err := vpcService.WaitForNatGateway(d.Id(), Available, DefaultTimeout); err != nil {
 		return WrapError(err)
 	}
+	if err := vpcService.setInstanceTags(d, TagResourceNatGateway); err != nil {
+		return WrapError(err)
+	}
 	return resourceApsaraStackNatGatewayRead(d, meta)
 }
 
@@ -175,6 +184,12 @@ func resourceApsaraStackNatGatewayRead(d *schema.ResourceData, meta interface{})
 	d.Set("forward_table_ids", strings.Join(object.ForwardTableIds.ForwardTableId, ","))
 	d.Set("description", object.Description)
 	d.Set("vpc_id", object.VpcId)
+	d.Set("resource_group_id", object.ResourceGroupId)
+	tags, err := vpcService.DescribeTags(d.Id(), nil, TagResourceNatGateway)
+	if err != nil {
+		return WrapError(err)
+	}
+	d.Set("tags", vpcService.tagsToMap(tags))
 	bindWidthPackages, err := flattenBandWidthPackages(object.BandwidthPackageIds.BandwidthPackageId, meta, d)
 	if err != nil {
 		return WrapError(err)
@@ -196,6 +211,10 @@ func resourceApsaraStackNatGatewayUpdate(d *schema.ResourceData, meta interface{
 	}
 
 	d.Partial(true)
+	if err := vpcService.setInstanceTags(d, TagResourceNatGateway); err != nil {
+		return WrapError(err)
+	}
+
 	attributeUpdate := false
 	modifyNatGatewayAttributeRequest := vpc.CreateModifyNatGatewayAttributeRequest()
 	if strings.ToLower(client.Config.Protocol) == "https" {
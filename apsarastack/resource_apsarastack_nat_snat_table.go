@@ -0,0 +1,292 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceApsaraStackNatSnatTable() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackNatSnatTableCreate,
+		Read:   resourceApsaraStackNatSnatTableRead,
+		Update: resourceApsaraStackNatSnatTableUpdate,
+		Delete: resourceApsaraStackNatSnatTableDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"snat_table_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"entries": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"snat_ip": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"source_vswitch_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"source_cidr": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"snat_entry_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Set: natSnatTableEntryHash,
+			},
+		},
+	}
+}
+
+func resourceApsaraStackNatSnatTableCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	tableId := d.Get("snat_table_id").(string)
+
+	entries, err := vpcService.DescribeNatSnatTable(tableId)
+	if err != nil {
+		return WrapError(err)
+	}
+	d.SetId(tableId)
+
+	current := schema.NewSet(natSnatTableEntryHash, flattenNatSnatTableEntries(entries))
+	if err := updateNatSnatTableEntries(d, meta, current, d.Get("entries").(*schema.Set), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return WrapError(err)
+	}
+
+	return resourceApsaraStackNatSnatTableRead(d, meta)
+}
+
+func resourceApsaraStackNatSnatTableRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	entries, err := vpcService.DescribeNatSnatTable(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("snat_table_id", d.Id())
+	if err := d.Set("entries", flattenNatSnatTableEntries(entries)); err != nil {
+		return WrapError(err)
+	}
+	return nil
+}
+
+func resourceApsaraStackNatSnatTableUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("entries") {
+		o, n := d.GetChange("entries")
+		d.Partial(true)
+		if err := updateNatSnatTableEntries(d, meta, o.(*schema.Set), n.(*schema.Set), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return WrapError(err)
+		}
+		d.SetPartial("entries")
+		d.Partial(false)
+	}
+	return resourceApsaraStackNatSnatTableRead(d, meta)
+}
+
+func resourceApsaraStackNatSnatTableDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	entries, err := vpcService.DescribeNatSnatTable(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	current := schema.NewSet(natSnatTableEntryHash, flattenNatSnatTableEntries(entries))
+	empty := schema.NewSet(natSnatTableEntryHash, nil)
+	return WrapError(updateNatSnatTableEntries(d, meta, current, empty, d.Timeout(schema.TimeoutDelete)))
+}
+
+// updateNatSnatTableEntries works like updateNatForwardTableEntries.
+func updateNatSnatTableEntries(d *schema.ResourceData, meta interface{}, oldEntries, newEntries *schema.Set, timeout time.Duration) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+
+	remove := oldEntries.Difference(newEntries).List()
+	for start := 0; start < len(remove); start += natTableBatchSize {
+		end := start + natTableBatchSize
+		if end > len(remove) {
+			end = len(remove)
+		}
+		var entryIds []string
+		for _, e := range remove[start:end] {
+			entryId := e.(map[string]interface{})["snat_entry_id"].(string)
+			if entryId == "" {
+				continue
+			}
+			if err := deleteNatSnatTableEntry(client, d.Id(), entryId, timeout); err != nil {
+				return WrapError(err)
+			}
+			entryIds = append(entryIds, entryId)
+		}
+		if len(entryIds) < 1 {
+			continue
+		}
+		stateConf := BuildStateConf([]string{"Pending", string(Available)}, []string{string(Deleted)}, timeout, 3*time.Second, vpcService.NatSnatTableEntriesStateRefreshFunc(d.Id(), entryIds))
+		if _, err := stateConf.WaitForState(); err != nil {
+			return WrapErrorf(err, IdMsg, d.Id())
+		}
+	}
+
+	add := newEntries.Difference(oldEntries).List()
+	for start := 0; start < len(add); start += natTableBatchSize {
+		end := start + natTableBatchSize
+		if end > len(add) {
+			end = len(add)
+		}
+		var entryIds []string
+		for _, e := range add[start:end] {
+			entryId, err := createNatSnatTableEntry(client, d.Id(), e.(map[string]interface{}), timeout)
+			if err != nil {
+				return WrapError(err)
+			}
+			entryIds = append(entryIds, entryId)
+		}
+		stateConf := BuildStateConf([]string{"Pending", string(Deleted)}, []string{string(Available)}, timeout, 3*time.Second, vpcService.NatSnatTableEntriesStateRefreshFunc(d.Id(), entryIds))
+		if _, err := stateConf.WaitForState(); err != nil {
+			return WrapErrorf(err, IdMsg, d.Id())
+		}
+	}
+	return nil
+}
+
+func createNatSnatTableEntry(client *connectivity.ApsaraStackClient, tableId string, entry map[string]interface{}, timeout time.Duration) (string, error) {
+	request := vpc.CreateCreateSnatEntryRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.SnatTableId = tableId
+	request.SnatIp = entry["snat_ip"].(string)
+	if v, ok := entry["source_vswitch_id"].(string); ok && v != "" {
+		request.SourceVSwitchId = v
+	}
+	if v, ok := entry["source_cidr"].(string); ok && v != "" {
+		request.SourceCIDR = v
+	}
+	if v, ok := entry["name"].(string); ok && v != "" {
+		request.SnatEntryName = v
+	}
+
+	var response *vpc.CreateSnatEntryResponse
+	wait := incrementalWait(1*time.Second, 1*time.Second)
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.CreateSnatEntry(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"EIP_NOT_IN_GATEWAY", "OperationUnsupported.EipNatBWPCheck", "OperationUnsupported.EipInBinding", "TaskConflict", "OperationConflict", Throttling}) {
+				wait()
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ = raw.(*vpc.CreateSnatEntryResponse)
+		return nil
+	})
+	if err != nil {
+		return "", WrapErrorf(err, DefaultErrorMsg, tableId, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	return response.SnatEntryId, nil
+}
+
+func deleteNatSnatTableEntry(client *connectivity.ApsaraStackClient, tableId, entryId string, timeout time.Duration) error {
+	request := vpc.CreateDeleteSnatEntryRequest()
+	request.RegionId = client.RegionId
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.SnatTableId = tableId
+	request.SnatEntryId = entryId
+
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeleteSnatEntry(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"IncorretSnatEntryStatus", "TaskConflict", "OperationConflict", Throttling}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		if IsExpectedErrors(err, []string{"InvalidSnatTableId.NotFound", "InvalidSnatEntryId.NotFound"}) {
+			return nil
+		}
+		return WrapErrorf(err, DefaultErrorMsg, tableId+COLON_SEPARATED+entryId, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	return nil
+}
+
+func flattenNatSnatTableEntries(entries []vpc.SnatTableEntry) []interface{} {
+	var result []interface{}
+	for _, entry := range entries {
+		m := map[string]interface{}{
+			"snat_ip":           entry.SnatIp,
+			"source_vswitch_id": entry.SourceVSwitchId,
+			"source_cidr":       "",
+			"name":              entry.SnatEntryName,
+			"snat_entry_id":     entry.SnatEntryId,
+		}
+		// Entries bound to a vSwitch also report the vSwitch CIDR block, which is not part of their configuration.
+		if entry.SourceVSwitchId == "" {
+			m["source_cidr"] = entry.SourceCIDR
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+// natSnatTableEntryHash leaves out snat_entry_id so that configured entries match the ones read back.
+func natSnatTableEntryHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	return hashcode.String(fmt.Sprintf("%s|%s|%s|%s", m["snat_ip"], m["source_vswitch_id"], m["source_cidr"], m["name"]))
+}
@@ -0,0 +1,131 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccApsaraStackNatSnatTableBasic(t *testing.T) {
+	resourceId := "apsarastack_nat_snat_table.default"
+	rand := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckNatSnatTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNatSnatTableConfigBasic(rand),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "entries.#", "1"),
+					testAccCheckNatSnatTableSources(resourceId, 1, 0),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccNatSnatTableConfigMulti(rand),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceId, "entries.#", "2"),
+					testAccCheckNatSnatTableSources(resourceId, 1, 1),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckNatSnatTableSources checks how many entries take their source from a vswitch and from a cidr block.
+func testAccCheckNatSnatTableSources(resourceId string, vswitches, cidrs int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceId]
+		if !ok {
+			return WrapError(fmt.Errorf("Not found: %s", resourceId))
+		}
+		gotVswitches, gotCidrs := 0, 0
+		for key, value := range rs.Primary.Attributes {
+			switch {
+			case strings.HasSuffix(key, ".source_vswitch_id") && value != "":
+				gotVswitches++
+			case strings.HasSuffix(key, ".source_cidr") && value != "":
+				gotCidrs++
+			}
+		}
+		if gotVswitches != vswitches || gotCidrs != cidrs {
+			return WrapError(fmt.Errorf("expected %d vswitch and %d cidr entries, got %d and %d", vswitches, cidrs, gotVswitches, gotCidrs))
+		}
+		return nil
+	}
+}
+
+func testAccCheckNatSnatTableDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "apsarastack_nat_snat_table" {
+			continue
+		}
+		entries, err := vpcService.DescribeNatSnatTable(rs.Primary.ID)
+		if err != nil {
+			if NotFoundError(err) {
+				continue
+			}
+			return WrapError(err)
+		}
+		if len(entries) > 0 {
+			return WrapError(fmt.Errorf("Snat table %s still has %d entries", rs.Primary.ID, len(entries)))
+		}
+	}
+	return nil
+}
+
+func testAccNatSnatTableConfigBasic(rand int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "apsarastack_nat_snat_table" "default" {
+  snat_table_id = "${apsarastack_nat_gateway.default.snat_table_ids}"
+  entries {
+    name              = "${var.name}"
+    snat_ip           = "${apsarastack_eip.default.0.ip_address}"
+    source_vswitch_id = "${apsarastack_vswitch.default.id}"
+  }
+
+  depends_on = ["apsarastack_eip_association.default"]
+}
+`, testAccForwardEntryConfigCommon(rand))
+}
+
+func testAccNatSnatTableConfigMulti(rand int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "apsarastack_nat_snat_table" "default" {
+  snat_table_id = "${apsarastack_nat_gateway.default.snat_table_ids}"
+  entries {
+    name              = "${var.name}"
+    snat_ip           = "${apsarastack_eip.default.0.ip_address}"
+    source_vswitch_id = "${apsarastack_vswitch.default.id}"
+  }
+  entries {
+    name        = "${var.name}"
+    snat_ip     = "${apsarastack_eip.default.1.ip_address}"
+    source_cidr = "172.16.8.0/24"
+  }
+
+  depends_on = ["apsarastack_eip_association.default"]
+}
+`, testAccForwardEntryConfigCommon(rand))
+}
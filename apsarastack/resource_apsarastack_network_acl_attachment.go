@@ -15,6 +15,9 @@ func resourceApsaraStackNetworkAclAttachment() *schema.Resource {
 		Read:   resourceApsaraStackNetworkAclAttachmentRead,
 		Update: resourceApsaraStackNetworkAclAttachmentUpdate,
 		Delete: resourceApsaraStackNetworkAclAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: networkAclIdImporter,
+		},
 
 		Schema: map[string]*schema.Schema{
 
@@ -59,16 +62,7 @@ func resourceApsaraStackNetworkAclAttachmentRead(d *schema.ResourceData, meta in
 		return WrapError(err)
 	}
 	networkAclId := parts[0]
-	vpcResource := []vpc.Resource{}
-	for _, e := range d.Get("resources").(*schema.Set).List() {
-		resourceId := e.(map[string]interface{})["resource_id"]
-		resourceType := e.(map[string]interface{})["resource_type"]
-		vpcResource = append(vpcResource, vpc.Resource{
-			ResourceId:   resourceId.(string),
-			ResourceType: resourceType.(string),
-		})
-	}
-	err = vpcService.DescribeNetworkAclAttachment(networkAclId, vpcResource)
+	object, err := vpcService.DescribeNetworkAcl(networkAclId)
 	if err != nil {
 		if NotFoundError(err) {
 			d.SetId("")
@@ -76,8 +70,26 @@ func resourceApsaraStackNetworkAclAttachmentRead(d *schema.ResourceData, meta in
 		}
 		return WrapError(err)
 	}
+	// Read the bound resources from the network acl so that resources bound or unbound outside of Terraform show up as a diff.
+	var resources []map[string]interface{}
+	if resourceList, ok := object["Resources"].(map[string]interface{})["Resource"].([]interface{}); ok {
+		for _, v := range resourceList {
+			if item, ok := v.(map[string]interface{}); ok {
+				resources = append(resources, map[string]interface{}{
+					"resource_id":   item["ResourceId"],
+					"resource_type": item["ResourceType"],
+				})
+			}
+		}
+	}
+	if len(resources) < 1 {
+		d.SetId("")
+		return nil
+	}
 	d.Set("network_acl_id", networkAclId)
-	d.Set("resources", vpcResource)
+	if err := d.Set("resources", resources); err != nil {
+		return WrapError(err)
+	}
 	return nil
 }
 
@@ -1,14 +1,19 @@
 package apsarastack
 
 import (
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
 	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
 func resourceApsaraStackNetworkAclEntries() *schema.Resource {
@@ -18,7 +23,7 @@ func resourceApsaraStackNetworkAclEntries() *schema.Resource {
 		Update: resourceApsaraStackNetworkAclEntriesUpdate,
 		Delete: resourceApsaraStackNetworkAclEntriesDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: networkAclIdImporter,
 		},
 		Schema: map[string]*schema.Schema{
 
@@ -28,90 +33,60 @@ func resourceApsaraStackNetworkAclEntries() *schema.Resource {
 				ForceNew: true,
 			},
 			"ingress": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"description": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"source_cidr_ip": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"entry_type": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"name": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"policy": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"port": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"protocol": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-					},
-				},
+				Elem:     networkAclEntrySchema("source_cidr_ip"),
+				Set:      networkAclEntryHash("source_cidr_ip"),
 			},
 			"egress": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"description": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"destination_cidr_ip": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"entry_type": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"name": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"policy": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"port": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"protocol": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-					},
-				},
+				Elem:     networkAclEntrySchema("destination_cidr_ip"),
+				Set:      networkAclEntryHash("destination_cidr_ip"),
+			},
+		},
+	}
+}
+
+func networkAclEntrySchema(cidrKey string) *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			cidrKey: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"entry_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "custom",
+				ValidateFunc: validation.StringInSlice([]string{"custom"}, false),
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"policy": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"accept", "drop"}, false),
+			},
+			"port": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"icmp", "gre", "tcp", "udp", "all"}, false),
 			},
 		},
 	}
@@ -124,6 +99,14 @@ func resourceApsaraStackNetworkAclEntriesCreate(d *schema.ResourceData, meta int
 	return resourceApsaraStackNetworkAclEntriesUpdate(d, meta)
 }
 
+// networkAclIdImporter lets resources bound to a network ACL be imported by the network ACL id alone.
+func networkAclIdImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if !strings.Contains(d.Id(), COLON_SEPARATED) {
+		d.SetId(d.Id() + COLON_SEPARATED + resource.UniqueId())
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceApsaraStackNetworkAclEntriesRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.ApsaraStackClient)
 	vpcService := VpcService{client}
@@ -179,8 +162,12 @@ func resourceApsaraStackNetworkAclEntriesRead(d *schema.ResourceData, meta inter
 	}
 
 	d.Set("network_acl_id", object["NetworkAclId"])
-	d.Set("egress", egress)
-	d.Set("ingress", ingress)
+	if err := d.Set("ingress", setNetworkAclEntryPriorities(ingress, d.Get("ingress").(*schema.Set).List(), "source_cidr_ip")); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("egress", setNetworkAclEntryPriorities(egress, d.Get("egress").(*schema.Set).List(), "destination_cidr_ip")); err != nil {
+		return WrapError(err)
+	}
 
 	return nil
 }
@@ -199,16 +186,20 @@ func resourceApsaraStackNetworkAclEntriesUpdate(d *schema.ResourceData, meta int
 	request.RegionId = client.RegionId
 	request.NetworkAclId = networkAclId
 	if d.HasChange("ingress") {
+		entries, err := sortNetworkAclEntries(d.Get("ingress").(*schema.Set), "source_cidr_ip")
+		if err != nil {
+			return WrapError(err)
+		}
 		ingress := []vpc.UpdateNetworkAclEntriesIngressAclEntries{}
-		for _, e := range d.Get("ingress").([]interface{}) {
+		for _, e := range entries {
 			ingress = append(ingress, vpc.UpdateNetworkAclEntriesIngressAclEntries{
-				Protocol:            e.(map[string]interface{})["protocol"].(string),
-				Port:                e.(map[string]interface{})["port"].(string),
-				SourceCidrIp:        e.(map[string]interface{})["source_cidr_ip"].(string),
-				NetworkAclEntryName: e.(map[string]interface{})["name"].(string),
-				EntryType:           e.(map[string]interface{})["entry_type"].(string),
-				Policy:              e.(map[string]interface{})["policy"].(string),
-				Description:         e.(map[string]interface{})["description"].(string),
+				Protocol:            e["protocol"].(string),
+				Port:                e["port"].(string),
+				SourceCidrIp:        e["source_cidr_ip"].(string),
+				NetworkAclEntryName: e["name"].(string),
+				EntryType:           e["entry_type"].(string),
+				Policy:              e["policy"].(string),
+				Description:         e["description"].(string),
 			})
 		}
 		request.IngressAclEntries = &ingress
@@ -216,16 +207,20 @@ func resourceApsaraStackNetworkAclEntriesUpdate(d *schema.ResourceData, meta int
 	}
 
 	if d.HasChange("egress") {
+		entries, err := sortNetworkAclEntries(d.Get("egress").(*schema.Set), "destination_cidr_ip")
+		if err != nil {
+			return WrapError(err)
+		}
 		egress := []vpc.UpdateNetworkAclEntriesEgressAclEntries{}
-		for _, e := range d.Get("egress").([]interface{}) {
+		for _, e := range entries {
 			egress = append(egress, vpc.UpdateNetworkAclEntriesEgressAclEntries{
-				Protocol:            e.(map[string]interface{})["protocol"].(string),
-				Port:                e.(map[string]interface{})["port"].(string),
-				DestinationCidrIp:   e.(map[string]interface{})["destination_cidr_ip"].(string),
-				NetworkAclEntryName: e.(map[string]interface{})["name"].(string),
-				EntryType:           e.(map[string]interface{})["entry_type"].(string),
-				Policy:              e.(map[string]interface{})["policy"].(string),
-				Description:         e.(map[string]interface{})["description"].(string),
+				Protocol:            e["protocol"].(string),
+				Port:                e["port"].(string),
+				DestinationCidrIp:   e["destination_cidr_ip"].(string),
+				NetworkAclEntryName: e["name"].(string),
+				EntryType:           e["entry_type"].(string),
+				Policy:              e["policy"].(string),
+				Description:         e["description"].(string),
 			})
 		}
 		request.EgressAclEntries = &egress
@@ -244,6 +239,7 @@ func resourceApsaraStackNetworkAclEntriesUpdate(d *schema.ResourceData, meta int
 			if IsExpectedErrors(err, []string{"TaskConflict"}) {
 				return resource.RetryableError(err)
 			}
+			return resource.NonRetryableError(err)
 		}
 		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
 		return nil
@@ -287,6 +283,7 @@ func resourceApsaraStackNetworkAclEntriesDelete(d *schema.ResourceData, meta int
 			if IsExpectedErrors(err, []string{"TaskConflict"}) {
 				return resource.RetryableError(err)
 			}
+			return resource.NonRetryableError(err)
 		}
 		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
 		return nil
@@ -296,3 +293,84 @@ func resourceApsaraStackNetworkAclEntriesDelete(d *schema.ResourceData, meta int
 	}
 	return vpcService.WaitForNetworkAcl(networkAclId, Available, DefaultTimeout)
 }
+
+// networkAclEntryKey identifies an entry by everything but its priority.
+func networkAclEntryKey(m map[string]interface{}, cidrKey string) string {
+	return fmt.Sprintf("%v|%v|%v|%v|%v|%v", m[cidrKey], m["policy"], m["port"], m["protocol"], m["name"], m["description"])
+}
+
+func networkAclEntryHash(cidrKey string) schema.SchemaSetFunc {
+	return func(v interface{}) int {
+		m := v.(map[string]interface{})
+		return hashcode.String(fmt.Sprintf("%v|%s", m["priority"], networkAclEntryKey(m, cidrKey)))
+	}
+}
+
+// sortNetworkAclEntries orders the entries by priority, which is the order the network ACL evaluates them in.
+// Entries without a priority are evaluated after all the others.
+func sortNetworkAclEntries(set *schema.Set, cidrKey string) ([]map[string]interface{}, error) {
+	var entries []map[string]interface{}
+	priorities := make(map[int]bool)
+	for _, e := range set.List() {
+		m := e.(map[string]interface{})
+		if priority := m["priority"].(int); priority > 0 {
+			if priorities[priority] {
+				return nil, fmt.Errorf("the priority %d is used by more than one network acl entry", priority)
+			}
+			priorities[priority] = true
+		}
+		entries = append(entries, m)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		pi, pj := entries[i]["priority"].(int), entries[j]["priority"].(int)
+		if pi == 0 || pj == 0 {
+			if pi == pj {
+				return networkAclEntryKey(entries[i], cidrKey) < networkAclEntryKey(entries[j], cidrKey)
+			}
+			return pj == 0
+		}
+		return pi < pj
+	})
+	return entries, nil
+}
+
+// setNetworkAclEntryPriorities gives the entries read back from the network ACL the priorities they have in the
+// configuration. Entries that are not in the configuration get their position as priority, and if the network ACL
+// evaluates the configured entries in another order than their priorities, every entry gets its position, so
+// changes made outside of Terraform show up as a diff.
+func setNetworkAclEntryPriorities(entries []map[string]interface{}, configured []interface{}, cidrKey string) []map[string]interface{} {
+	priorities := make(map[string][]int)
+	for _, e := range configured {
+		m := e.(map[string]interface{})
+		key := networkAclEntryKey(m, cidrKey)
+		priorities[key] = append(priorities[key], m["priority"].(int))
+	}
+	for _, p := range priorities {
+		sort.Ints(p)
+	}
+
+	inOrder, unprioritized, last := true, false, 0
+	for i, entry := range entries {
+		key := networkAclEntryKey(entry, cidrKey)
+		if p := priorities[key]; len(p) > 0 {
+			entry["priority"] = p[0]
+			priorities[key] = p[1:]
+			if p[0] == 0 {
+				unprioritized = true
+			} else {
+				if unprioritized || p[0] <= last {
+					inOrder = false
+				}
+				last = p[0]
+			}
+			continue
+		}
+		entry["priority"] = i + 1
+	}
+	if !inOrder {
+		for i, entry := range entries {
+			entry["priority"] = i + 1
+		}
+	}
+	return entries
+}
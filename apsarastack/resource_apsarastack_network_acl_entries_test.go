@@ -2,6 +2,7 @@ package apsarastack
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
@@ -12,12 +13,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
-func SkipTestAccApsaraStackVpcNetworkAclEntries_basic(t *testing.T) {
+func TestAccApsaraStackVpcNetworkAclEntries_basic(t *testing.T) {
 	resourceId := "apsarastack_network_acl_entries.default"
 	ra := resourceAttrInit(resourceId, testAccNaclEntriesCheckMap)
 	rand := acctest.RandInt()
 	testAccCheck := ra.resourceAttrMapUpdateSet()
 	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
 		// module name
 		IDRefreshName: resourceId,
 		Providers:     testAccProviders,
@@ -28,22 +32,24 @@ func SkipTestAccApsaraStackVpcNetworkAclEntries_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckNetworkAclEntriesExists(resourceId),
 					testAccCheck(map[string]string{
-						"ingress.#":                    "1",
-						"egress.#":                     "1",
-						"ingress.0.description":        "tf-testAcc_network_acl",
-						"ingress.0.entry_type":         "custom",
-						"ingress.0.name":               "tf-testAcc_network_acl",
-						"ingress.0.policy":             "accept",
-						"ingress.0.port":               "-1/-1",
-						"ingress.0.protocol":           "all",
-						"ingress.0.source_cidr_ip":     "0.0.0.0/32",
-						"egress.0.description":         "tf-testAcc_network_acl",
-						"egress.0.destination_cidr_ip": "0.0.0.0/32",
-						"egress.0.entry_type":          "custom",
-						"egress.0.name":                "tf-testAcc_network_acl",
-						"egress.0.policy":              "accept",
-						"egress.0.port":                "-1/-1",
-						"egress.0.protocol":            "all",
+						"ingress.#": "1",
+						"egress.#":  "1",
+					}),
+					testAccCheckNetworkAclEntry(resourceId, "ingress", map[string]string{
+						"priority":       "1",
+						"source_cidr_ip": "0.0.0.0/32",
+						"protocol":       "all",
+						"port":           "-1/-1",
+						"policy":         "accept",
+						"entry_type":     "custom",
+					}),
+					testAccCheckNetworkAclEntry(resourceId, "egress", map[string]string{
+						"priority":            "1",
+						"destination_cidr_ip": "0.0.0.0/32",
+						"protocol":            "all",
+						"port":                "-1/-1",
+						"policy":              "accept",
+						"entry_type":          "custom",
 					}),
 				),
 			},
@@ -52,27 +58,71 @@ func SkipTestAccApsaraStackVpcNetworkAclEntries_basic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				// Importing by the network ACL id alone reads the same entries.
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateIdFunc: testAccNetworkAclEntriesImportStateIdFunc(resourceId),
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return WrapError(Error("expected 1 imported state, got %d", len(states)))
+					}
+					attributes := states[0].Attributes
+					if attributes["ingress.#"] != "1" || attributes["egress.#"] != "1" {
+						return WrapError(Error("expected 1 ingress and 1 egress entry, got %s and %s", attributes["ingress.#"], attributes["egress.#"]))
+					}
+					return nil
+				},
+			},
 			{
 				Config: testAccNetworkAclEntries_modify(rand),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckNetworkAclEntriesExists(resourceId),
 					testAccCheck(map[string]string{
-						"ingress.#":                    "2",
-						"egress.#":                     "2",
-						"ingress.0.description":        "tf-testAcc_network_acl",
-						"ingress.0.entry_type":         "custom",
-						"ingress.0.name":               "tf-testAcc_network_acl",
-						"ingress.0.policy":             "accept",
-						"ingress.0.port":               "-1/-1",
-						"ingress.0.protocol":           "all",
-						"ingress.0.source_cidr_ip":     "0.0.0.0/32",
-						"egress.0.description":         "tf-testAcc_network_acl",
-						"egress.0.destination_cidr_ip": "0.0.0.0/32",
-						"egress.0.entry_type":          "custom",
-						"egress.0.name":                "tf-testAcc_network_acl",
-						"egress.0.policy":              "accept",
-						"egress.0.port":                "-1/-1",
-						"egress.0.protocol":            "all",
+						"ingress.#": "2",
+						"egress.#":  "2",
+					}),
+					testAccCheckNetworkAclEntry(resourceId, "ingress", map[string]string{
+						"priority":       "1",
+						"source_cidr_ip": "0.0.0.0/32",
+					}),
+					testAccCheckNetworkAclEntry(resourceId, "ingress", map[string]string{
+						"priority":       "2",
+						"source_cidr_ip": "0.0.0.1/32",
+					}),
+					testAccCheckNetworkAclEntry(resourceId, "egress", map[string]string{
+						"priority":            "1",
+						"destination_cidr_ip": "0.0.0.0/32",
+					}),
+					testAccCheckNetworkAclEntry(resourceId, "egress", map[string]string{
+						"priority":            "2",
+						"destination_cidr_ip": "0.0.0.1/32",
+					}),
+				),
+			},
+			{
+				Config: testAccNetworkAclEntries_reorder(rand),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkAclEntriesExists(resourceId),
+					testAccCheck(map[string]string{
+						"ingress.#": "2",
+						"egress.#":  "2",
+					}),
+					testAccCheckNetworkAclEntry(resourceId, "ingress", map[string]string{
+						"priority":       "3",
+						"source_cidr_ip": "0.0.0.0/32",
+					}),
+					testAccCheckNetworkAclEntry(resourceId, "ingress", map[string]string{
+						"priority":       "2",
+						"source_cidr_ip": "0.0.0.1/32",
+					}),
+					testAccCheckNetworkAclEntry(resourceId, "egress", map[string]string{
+						"priority":            "1",
+						"destination_cidr_ip": "0.0.0.0/32",
+					}),
+					testAccCheckNetworkAclEntry(resourceId, "egress", map[string]string{
+						"priority":            "2",
+						"destination_cidr_ip": "0.0.0.1/32",
 					}),
 				),
 			},
@@ -81,22 +131,16 @@ func SkipTestAccApsaraStackVpcNetworkAclEntries_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckNetworkAclEntriesExists(resourceId),
 					testAccCheck(map[string]string{
-						"ingress.#":                    "1",
-						"egress.#":                     "1",
-						"ingress.0.description":        "tf-testAcc_network_acl",
-						"ingress.0.entry_type":         "custom",
-						"ingress.0.name":               "tf-testAcc_network_acl",
-						"ingress.0.policy":             "accept",
-						"ingress.0.port":               "-1/-1",
-						"ingress.0.protocol":           "all",
-						"ingress.0.source_cidr_ip":     "0.0.0.0/32",
-						"egress.0.description":         "tf-testAcc_network_acl",
-						"egress.0.destination_cidr_ip": "0.0.0.0/32",
-						"egress.0.entry_type":          "custom",
-						"egress.0.name":                "tf-testAcc_network_acl",
-						"egress.0.policy":              "accept",
-						"egress.0.port":                "-1/-1",
-						"egress.0.protocol":            "all",
+						"ingress.#": "1",
+						"egress.#":  "1",
+					}),
+					testAccCheckNetworkAclEntry(resourceId, "ingress", map[string]string{
+						"priority":       "1",
+						"source_cidr_ip": "0.0.0.0/32",
+					}),
+					testAccCheckNetworkAclEntry(resourceId, "egress", map[string]string{
+						"priority":            "1",
+						"destination_cidr_ip": "0.0.0.0/32",
 					}),
 				),
 			},
@@ -104,6 +148,51 @@ func SkipTestAccApsaraStackVpcNetworkAclEntries_basic(t *testing.T) {
 	})
 }
 
+// testAccCheckNetworkAclEntry checks that one of the ingress or egress entries has all of the given attributes.
+// The entries are a set, so they are looked up by their values instead of their index.
+func testAccCheckNetworkAclEntry(n, direction string, attrs map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return WrapError(Error("Not found: %s", n))
+		}
+		entries := make(map[string]map[string]string)
+		for key, value := range rs.Primary.Attributes {
+			parts := strings.SplitN(key, ".", 3)
+			if len(parts) != 3 || parts[0] != direction {
+				continue
+			}
+			if entries[parts[1]] == nil {
+				entries[parts[1]] = make(map[string]string)
+			}
+			entries[parts[1]][parts[2]] = value
+		}
+		for _, entry := range entries {
+			matched := true
+			for key, value := range attrs {
+				if entry[key] != value {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				return nil
+			}
+		}
+		return WrapError(Error("%s: no %s entry matches %v", n, direction, attrs))
+	}
+}
+
+func testAccNetworkAclEntriesImportStateIdFunc(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", WrapError(Error("Not found: %s", n))
+		}
+		return rs.Primary.Attributes["network_acl_id"], nil
+	}
+}
+
 func testAccCheckNetworkAclEntriesExists(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -189,6 +278,7 @@ resource "apsarastack_network_acl_entries" "default" {
   ingress {
       protocol = "all"
       port = "-1/-1"
+      priority = 1
       source_cidr_ip = "0.0.0.0/32"
       name = "${var.name}"
       entry_type = "custom"
@@ -199,6 +289,7 @@ resource "apsarastack_network_acl_entries" "default" {
   egress {
       protocol = "all"
       port = "-1/-1"
+      priority = 1
       destination_cidr_ip = "0.0.0.0/32"
       name = "${var.name}"
       entry_type = "custom"
@@ -234,6 +325,76 @@ resource "apsarastack_network_acl_entries" "default" {
   ingress  {
       protocol = "all"
       port = "-1/-1"
+      priority = 1
+      source_cidr_ip = "0.0.0.0/32"
+      name = "${var.name}"
+      entry_type = "custom"
+      policy = "accept"
+      description = "${var.name}"
+    }
+
+  ingress  {
+      protocol = "all"
+      port = "-1/-1"
+      priority = 2
+      source_cidr_ip = "0.0.0.1/32"
+      name = "${var.name}"
+      entry_type = "custom"
+      policy = "accept"
+      description = "${var.name}"
+    }
+  
+  egress {
+      protocol = "all"
+      port = "-1/-1"
+      priority = 1
+      destination_cidr_ip = "0.0.0.0/32"
+      name = "${var.name}"
+      entry_type = "custom"
+      policy = "accept"
+      description = "${var.name}"
+    }
+
+  egress {
+      protocol = "all"
+      port = "-1/-1"
+      priority = 2
+      destination_cidr_ip = "0.0.0.1/32"
+      name = "${var.name}"
+      entry_type = "custom"
+      policy = "accept"
+      description = "${var.name}"
+    }
+}
+`, randInt)
+}
+
+func testAccNetworkAclEntries_reorder(randInt int) string {
+	return fmt.Sprintf(`
+variable "name" {
+	default = "tf-testAcc_network_acl"
+}
+
+data "apsarastack_zones" "default" {
+	available_resource_creation= "VSwitch"
+}
+
+resource "apsarastack_vpc" "default" {
+	name = "${var.name}"
+	cidr_block = "172.16.0.0/12"
+}
+
+resource "apsarastack_network_acl" "default" {
+	vpc_id = "${apsarastack_vpc.default.id}"
+	network_acl_name = "${var.name}%d"
+}
+
+resource "apsarastack_network_acl_entries" "default" {
+  network_acl_id = "${apsarastack_network_acl.default.id}"
+  ingress  {
+      protocol = "all"
+      port = "-1/-1"
+      priority = 3
       source_cidr_ip = "0.0.0.0/32"
       name = "${var.name}"
       entry_type = "custom"
@@ -244,6 +405,7 @@ resource "apsarastack_network_acl_entries" "default" {
   ingress  {
       protocol = "all"
       port = "-1/-1"
+      priority = 2
       source_cidr_ip = "0.0.0.1/32"
       name = "${var.name}"
       entry_type = "custom"
@@ -254,6 +416,7 @@ resource "apsarastack_network_acl_entries" "default" {
   egress {
       protocol = "all"
       port = "-1/-1"
+      priority = 1
       destination_cidr_ip = "0.0.0.0/32"
       name = "${var.name}"
       entry_type = "custom"
@@ -264,6 +427,7 @@ resource "apsarastack_network_acl_entries" "default" {
   egress {
       protocol = "all"
       port = "-1/-1"
+      priority = 2
       destination_cidr_ip = "0.0.0.1/32"
       name = "${var.name}"
       entry_type = "custom"
@@ -299,6 +463,7 @@ resource "apsarastack_network_acl_entries" "default" {
   ingress {
       protocol = "all"
       port = "-1/-1"
+      priority = 1
       source_cidr_ip = "0.0.0.0/32"
       name = "${var.name}"
       entry_type = "custom"
@@ -309,6 +474,7 @@ resource "apsarastack_network_acl_entries" "default" {
   egress {
       protocol = "all"
       port = "-1/-1"
+      priority = 1
       destination_cidr_ip = "0.0.0.0/32"
       name = "${var.name}"
       entry_type = "custom"
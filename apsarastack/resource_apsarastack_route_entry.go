@@ -20,6 +20,7 @@ func resourceApsaraStackRouteEntry() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceApsaraStackRouteEntryCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"router_id": {
@@ -39,14 +40,37 @@ func resourceApsaraStackRouteEntry() *schema.Resource {
 				ForceNew: true,
 			},
 			"nexthop_type": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validation.StringInSlice(GetAllRouteEntryNextHopType(), false),
+				ConflictsWith: []string{"next_hops"},
 			},
 			"nexthop_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"next_hops"},
+			},
+			"next_hops": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ForceNew:      true,
+				MinItems:      2,
+				ConflictsWith: []string{"nexthop_type", "nexthop_id"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nexthop_type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{string(NextHopInstance), string(NextHopNetworkInterface)}, false),
+						},
+						"nexthop_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
 			},
 			"name": {
 				Type:         schema.TypeString,
@@ -83,8 +107,21 @@ func resourceApsaraStackRouteEntryCreate(d *schema.ResourceData, meta interface{
 	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
 	request.RouteTableId = rtId
 	request.DestinationCidrBlock = cidr
-	request.NextHopType = nt
-	request.NextHopId = ni
+	if v, ok := d.GetOk("next_hops"); ok {
+		var nextHops []vpc.CreateRouteEntryNextHopList
+		for _, e := range v.(*schema.Set).List() {
+			nextHop := e.(map[string]interface{})
+			nextHops = append(nextHops, vpc.CreateRouteEntryNextHopList{
+				NextHopType: nextHop["nexthop_type"].(string),
+				NextHopId:   nextHop["nexthop_id"].(string),
+			})
+		}
+		request.NextHopList = &nextHops
+		nt, ni = string(NextHopEcmp), ""
+	} else {
+		request.NextHopType = nt
+		request.NextHopId = ni
+	}
 	request.ClientToken = buildClientToken(request.GetActionName())
 	request.RouteEntryName = d.Get("name").(string)
 	err = resource.Retry(10*time.Minute, func() *resource.RetryError {
@@ -96,7 +133,7 @@ func resourceApsaraStackRouteEntryCreate(d *schema.ResourceData, meta interface{
 			return vpcClient.CreateRouteEntry(&args)
 		})
 		if err != nil {
-			if IsExpectedErrors(err, []string{"TaskConflict", "IncorrectRouteEntryStatus", Throttling, "IncorrectVpcStatus"}) {
+			if IsExpectedErrors(err, []string{"TaskConflict", "IncorrectRouteEntryStatus", Throttling, "IncorrectVpcStatus", "IncorrectHaVipStatus"}) {
 				return resource.RetryableError(err)
 			}
 			return resource.NonRetryableError(err)
@@ -144,8 +181,21 @@ func resourceApsaraStackRouteEntryRead(d *schema.ResourceData, meta interface{})
 	d.Set("router_id", parts[1])
 	d.Set("route_table_id", object.RouteTableId)
 	d.Set("destination_cidrblock", object.DestinationCidrBlock)
-	d.Set("nexthop_type", object.NextHopType)
-	d.Set("nexthop_id", object.InstanceId)
+	if parts[3] == string(NextHopEcmp) {
+		var nextHops []map[string]interface{}
+		for _, nextHop := range object.NextHops.NextHop {
+			nextHops = append(nextHops, map[string]interface{}{
+				"nexthop_type": nextHop.NextHopType,
+				"nexthop_id":   nextHop.NextHopId,
+			})
+		}
+		if err := d.Set("next_hops", nextHops); err != nil {
+			return WrapError(err)
+		}
+	} else {
+		d.Set("nexthop_type", object.NextHopType)
+		d.Set("nexthop_id", object.InstanceId)
+	}
 	d.Set("name", object.RouteEntryName)
 	return nil
 }
@@ -219,5 +269,47 @@ func buildApsaraStackRouteEntryDeleteArgs(d *schema.ResourceData, meta interface
 		request.NextHopId = v
 	}
 
+	if v, ok := d.GetOk("next_hops"); ok {
+		var nextHops []vpc.DeleteRouteEntryNextHopList
+		for _, e := range v.(*schema.Set).List() {
+			nextHop := e.(map[string]interface{})
+			nextHops = append(nextHops, vpc.DeleteRouteEntryNextHopList{
+				NextHopType: nextHop["nexthop_type"].(string),
+				NextHopId:   nextHop["nexthop_id"].(string),
+			})
+		}
+		request.NextHopList = &nextHops
+	}
+
 	return request, nil
 }
+
+// resourceApsaraStackRouteEntryCustomizeDiff rejects next hop IDs that do not belong to the next hop type at plan time,
+// instead of letting CreateRouteEntry fail after its retries.
+func resourceApsaraStackRouteEntryCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.NewValueKnown("nexthop_type") && d.NewValueKnown("nexthop_id") {
+		if err := checkRouteEntryNextHopId(d.Get("nexthop_type").(string), d.Get("nexthop_id").(string)); err != nil {
+			return WrapError(err)
+		}
+	}
+	if d.NewValueKnown("next_hops") {
+		for _, e := range d.Get("next_hops").(*schema.Set).List() {
+			nextHop := e.(map[string]interface{})
+			if err := checkRouteEntryNextHopId(nextHop["nexthop_type"].(string), nextHop["nexthop_id"].(string)); err != nil {
+				return WrapError(err)
+			}
+		}
+	}
+	return nil
+}
+
+func checkRouteEntryNextHopId(nextHopType, nextHopId string) error {
+	if nextHopType == "" || nextHopId == "" {
+		return nil
+	}
+	prefix, ok := routeEntryNextHopIdPrefix[NextHopType(nextHopType)]
+	if ok && !strings.HasPrefix(nextHopId, prefix) {
+		return Error("nexthop_id %s is not a valid %s ID, it should start with %q.", nextHopId, nextHopType, prefix)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package apsarastack
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
@@ -154,6 +155,50 @@ func TestAccApsarastackRouteEntryMulti(t *testing.T) {
 	})
 }
 
+func TestAccApsarastackRouteEntryEcmp(t *testing.T) {
+	var v *vpc.RouteEntry
+	rand := acctest.RandIntRange(1000, 9999)
+	resourceId := "apsarastack_route_entry.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"route_table_id":        CHECKSET,
+		"destination_cidrblock": "172.11.1.0/24",
+		"next_hops.#":           "2",
+	})
+	serviceFunc := func() interface{} {
+		return &VpcService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckRouteEntryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRouteEntryConfig_invalidNextHop(rand),
+				ExpectError: regexp.MustCompile("is not a valid Instance ID"),
+			},
+			{
+				Config: testAccRouteEntryConfig_ecmp(rand),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testAccCheckRouteEntryDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*connectivity.ApsaraStackClient)
 	vpcService := VpcService{client}
@@ -371,3 +416,57 @@ var testAccRouteEntryCheckMap = map[string]string{
 	"nexthop_id":            CHECKSET,
 	"destination_cidrblock": "172.11.1.1/32",
 }
+
+func testAccRouteEntryConfig_invalidNextHop(rand int) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "tf-testAccRouteEntryEcmp%d"
+}
+
+resource "apsarastack_vpc" "default" {
+  name = "${var.name}"
+  cidr_block = "172.16.0.0/16"
+}
+
+resource "apsarastack_route_entry" "default" {
+  route_table_id = "${apsarastack_vpc.default.route_table_id}"
+  destination_cidrblock = "172.11.1.0/24"
+  nexthop_type = "Instance"
+  nexthop_id = "ngw-invalid"
+}
+`, rand)
+}
+
+func testAccRouteEntryConfig_ecmp(rand int) string {
+	return fmt.Sprintf(`
+%s
+
+variable "name" {
+  default = "tf-testAccRouteEntryEcmp%d"
+}
+
+resource "apsarastack_instance" "default" {
+  count = 2
+  vswitch_id = "${apsarastack_vswitch.default.id}"
+  image_id = "${data.apsarastack_images.default.images.0.id}"
+  availability_zone = "${data.apsarastack_zones.default.zones.0.id}"
+  system_disk_category = "cloud_efficiency"
+  instance_type = "${data.apsarastack_instance_types.default.instance_types.0.id}"
+  security_groups = ["${apsarastack_security_group.default.id}"]
+  instance_name = "${var.name}"
+}
+
+resource "apsarastack_route_entry" "default" {
+  route_table_id = "${apsarastack_vpc.default.route_table_id}"
+  destination_cidrblock = "172.11.1.0/24"
+  next_hops {
+    nexthop_type = "Instance"
+    nexthop_id = "${apsarastack_instance.default.0.id}"
+  }
+  next_hops {
+    nexthop_type = "Instance"
+    nexthop_id = "${apsarastack_instance.default.1.id}"
+  }
+}
+`, EcsInstanceCommonTestCase, rand)
+}
@@ -0,0 +1,401 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// securityGroupRulesBatchSize is the maximum number of permissions sent in one authorize or revoke call.
+const securityGroupRulesBatchSize = 100
+
+func resourceApsaraStackSecurityGroupRules() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackSecurityGroupRulesCreate,
+		Read:   resourceApsaraStackSecurityGroupRulesRead,
+		Update: resourceApsaraStackSecurityGroupRulesUpdate,
+		Delete: resourceApsaraStackSecurityGroupRulesDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"security_group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"ingress": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     securityGroupRulesElem(),
+				Set:      securityGroupRulesHash,
+			},
+			"egress": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     securityGroupRulesElem(),
+				Set:      securityGroupRulesHash,
+			},
+		},
+	}
+}
+
+func securityGroupRulesElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"ip_protocol": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"tcp", "udp", "icmp", "gre", "all"}, false),
+			},
+			"port_range": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"nic_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(GroupRuleIntranet),
+				ValidateFunc: validation.StringInSlice([]string{"internet", "intranet"}, false),
+			},
+			"policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(GroupRulePolicyAccept),
+				ValidateFunc: validation.StringInSlice([]string{"accept", "drop"}, false),
+			},
+			"priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"cidr_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"source_security_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"source_group_owner_account": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+// securityGroupRuleKey identifies a rule by the attributes the API matches on when revoking it.
+// The description is not part of the key, so that changing it modifies the rule in place.
+func securityGroupRuleKey(rule map[string]interface{}) string {
+	return strings.Join([]string{
+		strings.ToLower(rule["ip_protocol"].(string)),
+		rule["port_range"].(string),
+		rule["nic_type"].(string),
+		strings.ToLower(rule["policy"].(string)),
+		strconv.Itoa(rule["priority"].(int)),
+		rule["cidr_ip"].(string),
+		rule["source_security_group_id"].(string),
+		rule["source_group_owner_account"].(string),
+	}, ":")
+}
+
+func securityGroupRulesHash(v interface{}) int {
+	return hashcode.String(securityGroupRuleKey(v.(map[string]interface{})))
+}
+
+func resourceApsaraStackSecurityGroupRulesCreate(d *schema.ResourceData, meta interface{}) error {
+	d.SetId(d.Get("security_group_id").(string))
+	if err := applySecurityGroupRules(d, meta); err != nil {
+		return WrapError(err)
+	}
+	return resourceApsaraStackSecurityGroupRulesRead(d, meta)
+}
+
+func resourceApsaraStackSecurityGroupRulesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	group, err := ecsService.DescribeSecurityGroup(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	ingress, egress, err := flattenSecurityGroupPermissions(group.Permissions.Permission)
+	if err != nil {
+		return WrapError(err)
+	}
+	d.Set("security_group_id", group.SecurityGroupId)
+	if err := d.Set("ingress", ingress); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("egress", egress); err != nil {
+		return WrapError(err)
+	}
+	return nil
+}
+
+func resourceApsaraStackSecurityGroupRulesUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("ingress") || d.HasChange("egress") {
+		if err := applySecurityGroupRules(d, meta); err != nil {
+			return WrapError(err)
+		}
+	}
+	return resourceApsaraStackSecurityGroupRulesRead(d, meta)
+}
+
+func resourceApsaraStackSecurityGroupRulesDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	group, err := ecsService.DescribeSecurityGroup(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			return nil
+		}
+		return WrapError(err)
+	}
+	ingress, egress, err := flattenSecurityGroupPermissions(group.Permissions.Permission)
+	if err != nil {
+		return WrapError(err)
+	}
+	if err := batchSecurityGroupRules(client, d.Id(), "RevokeSecurityGroup", DirectionIngress, ingress); err != nil {
+		return WrapError(err)
+	}
+	return WrapError(batchSecurityGroupRules(client, d.Id(), "RevokeSecurityGroupEgress", DirectionEgress, egress))
+}
+
+// applySecurityGroupRules makes the rules of the security group exactly match the configuration:
+// rules which are not configured are revoked, missing rules are authorized and rules whose
+// description differs are modified.
+func applySecurityGroupRules(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	ecsService := EcsService{client}
+	group, err := ecsService.DescribeSecurityGroup(d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+	currentIngress, currentEgress, err := flattenSecurityGroupPermissions(group.Permissions.Permission)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	for _, direction := range []Direction{DirectionIngress, DirectionEgress} {
+		current := currentIngress
+		revokeAction, authorizeAction, modifyAction := "RevokeSecurityGroup", "AuthorizeSecurityGroup", "ModifySecurityGroupRule"
+		if direction == DirectionEgress {
+			current = currentEgress
+			revokeAction, authorizeAction, modifyAction = "RevokeSecurityGroupEgress", "AuthorizeSecurityGroupEgress", "ModifySecurityGroupEgressRule"
+		}
+
+		desired := make(map[string]map[string]interface{})
+		for _, raw := range d.Get(string(direction)).(*schema.Set).List() {
+			rule := raw.(map[string]interface{})
+			if err := validateSecurityGroupRule(group.VpcId, rule); err != nil {
+				return WrapError(err)
+			}
+			desired[securityGroupRuleKey(rule)] = rule
+		}
+		existing := make(map[string]map[string]interface{})
+		for _, rule := range current {
+			existing[securityGroupRuleKey(rule)] = rule
+		}
+
+		var revoke, authorize []map[string]interface{}
+		for key, rule := range existing {
+			if _, ok := desired[key]; !ok {
+				revoke = append(revoke, rule)
+			}
+		}
+		for key, rule := range desired {
+			old, ok := existing[key]
+			if !ok {
+				authorize = append(authorize, rule)
+				continue
+			}
+			if old["description"].(string) != rule["description"].(string) {
+				if err := modifySecurityGroupRuleDescription(client, d.Id(), modifyAction, direction, rule); err != nil {
+					return WrapError(err)
+				}
+			}
+		}
+
+		// Revoke first, so a rule which only changes its priority or policy does not conflict with itself.
+		if err := batchSecurityGroupRules(client, d.Id(), revokeAction, direction, revoke); err != nil {
+			return WrapError(err)
+		}
+		if err := batchSecurityGroupRules(client, d.Id(), authorizeAction, direction, authorize); err != nil {
+			return WrapError(err)
+		}
+	}
+	return nil
+}
+
+func validateSecurityGroupRule(vpcId string, rule map[string]interface{}) error {
+	if rule["cidr_ip"].(string) == "" && rule["source_security_group_id"].(string) == "" {
+		return fmt.Errorf("Either 'cidr_ip' or 'source_security_group_id' must be specified.")
+	}
+	if rule["cidr_ip"].(string) != "" && rule["source_security_group_id"].(string) != "" {
+		return fmt.Errorf("'cidr_ip' conflicts with 'source_security_group_id'.")
+	}
+	portRange := rule["port_range"].(string)
+	protocol := rule["ip_protocol"].(string)
+	if protocol == string(Tcp) || protocol == string(Udp) {
+		if portRange == AllPortRange {
+			return fmt.Errorf("'tcp' and 'udp' can support port range: [1, 65535]. Please correct it and try again.")
+		}
+	} else if portRange != AllPortRange {
+		return fmt.Errorf("'icmp', 'gre' and 'all' only support port range '-1/-1'. Please correct it and try again.")
+	}
+	if vpcId != "" || rule["source_security_group_id"].(string) != "" {
+		if GroupRuleNicType(rule["nic_type"].(string)) != GroupRuleIntranet {
+			return fmt.Errorf("When security group in the vpc or authorizing permission for source/destination security group, " + "the nic_type must be 'intranet'.")
+		}
+	}
+	return nil
+}
+
+func flattenSecurityGroupPermissions(permissions []ecs.Permission) (ingress, egress []map[string]interface{}, err error) {
+	for _, permission := range permissions {
+		priority, err := strconv.Atoi(permission.Priority)
+		if err != nil {
+			return nil, nil, WrapError(err)
+		}
+		rule := map[string]interface{}{
+			"ip_protocol": strings.ToLower(permission.IpProtocol),
+			"port_range":  permission.PortRange,
+			"nic_type":    permission.NicType,
+			"policy":      strings.ToLower(permission.Policy),
+			"priority":    priority,
+			"description": permission.Description,
+		}
+		if permission.Direction == string(DirectionEgress) {
+			rule["cidr_ip"] = permission.DestCidrIp
+			rule["source_security_group_id"] = permission.DestGroupId
+			rule["source_group_owner_account"] = permission.DestGroupOwnerAccount
+			egress = append(egress, rule)
+		} else {
+			rule["cidr_ip"] = permission.SourceCidrIp
+			rule["source_security_group_id"] = permission.SourceGroupId
+			rule["source_group_owner_account"] = permission.SourceGroupOwnerAccount
+			ingress = append(ingress, rule)
+		}
+	}
+	return ingress, egress, nil
+}
+
+func newSecurityGroupRulesRequest(client *connectivity.ApsaraStackClient, securityGroupId, action string) (*requests.CommonRequest, error) {
+	// Get product code from the built request
+	ruleReq := ecs.CreateModifySecurityGroupRuleRequest()
+	request, err := client.NewCommonRequest(ruleReq.GetProduct(), ruleReq.GetLocationServiceCode(), client.Config.Protocol, connectivity.ApiVersion20140526)
+	if err != nil {
+		return request, WrapError(err)
+	}
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.ApiName = action
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "ecs", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.QueryParams["RegionId"] = client.RegionId
+	request.QueryParams["SecurityGroupId"] = securityGroupId
+	return request, nil
+}
+
+// setSecurityGroupRuleParams writes the rule into the request query using the given key prefix,
+// which is empty for single rule actions and "Permissions.N." for batch actions.
+func setSecurityGroupRuleParams(query map[string]string, prefix string, direction Direction, rule map[string]interface{}) {
+	query[prefix+"IpProtocol"] = rule["ip_protocol"].(string)
+	query[prefix+"PortRange"] = rule["port_range"].(string)
+	query[prefix+"Policy"] = rule["policy"].(string)
+	query[prefix+"Priority"] = strconv.Itoa(rule["priority"].(int))
+	if v := rule["nic_type"].(string); v != "" {
+		query[prefix+"NicType"] = v
+	}
+	target := "Source"
+	if direction == DirectionEgress {
+		target = "Dest"
+	}
+	if v := rule["cidr_ip"].(string); v != "" {
+		query[prefix+target+"CidrIp"] = v
+	}
+	if v := rule["source_security_group_id"].(string); v != "" {
+		query[prefix+target+"GroupId"] = v
+	}
+	if v := rule["source_group_owner_account"].(string); v != "" {
+		query[prefix+target+"GroupOwnerAccount"] = v
+	}
+	if v, ok := rule["description"].(string); ok && v != "" {
+		query[prefix+"Description"] = v
+	}
+}
+
+func batchSecurityGroupRules(client *connectivity.ApsaraStackClient, securityGroupId, action string, direction Direction, rules []map[string]interface{}) error {
+	for start := 0; start < len(rules); start += securityGroupRulesBatchSize {
+		end := start + securityGroupRulesBatchSize
+		if end > len(rules) {
+			end = len(rules)
+		}
+		request, err := newSecurityGroupRulesRequest(client, securityGroupId, action)
+		if err != nil {
+			return WrapError(err)
+		}
+		for i, rule := range rules[start:end] {
+			setSecurityGroupRuleParams(request.QueryParams, fmt.Sprintf("Permissions.%d.", i+1), direction, rule)
+		}
+		var raw interface{}
+		err = resource.Retry(5*time.Minute, func() *resource.RetryError {
+			raw, err = client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+				return ecsClient.ProcessCommonRequest(request)
+			})
+			if err != nil {
+				if NeedRetry(err) || IsExpectedErrors(err, []string{"OperationConflict"}) {
+					return resource.RetryableError(err)
+				}
+				return resource.NonRetryableError(err)
+			}
+			return nil
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, securityGroupId, action, ApsaraStackSdkGoERROR)
+		}
+		addDebug(action, raw, request.Headers, request)
+	}
+	return nil
+}
+
+func modifySecurityGroupRuleDescription(client *connectivity.ApsaraStackClient, securityGroupId, action string, direction Direction, rule map[string]interface{}) error {
+	request, err := newSecurityGroupRulesRequest(client, securityGroupId, action)
+	if err != nil {
+		return WrapError(err)
+	}
+	setSecurityGroupRuleParams(request.QueryParams, "", direction, rule)
+	request.QueryParams["Description"] = rule["description"].(string)
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ProcessCommonRequest(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, securityGroupId, action, ApsaraStackSdkGoERROR)
+	}
+	addDebug(action, raw, request.Headers, request)
+	return nil
+}
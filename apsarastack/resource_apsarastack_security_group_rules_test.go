@@ -0,0 +1,125 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackSecurityGroupRules_basic(t *testing.T) {
+	var v ecs.DescribeSecurityGroupAttributeResponse
+	resourceId := "apsarastack_security_group_rules.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"security_group_id": CHECKSET,
+	})
+	rc := resourceCheckInitWithDescribeMethod(resourceId, &v, func() interface{} {
+		return &EcsService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}, "DescribeSecurityGroup")
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+	rand := acctest.RandIntRange(10000, 99999)
+	name := fmt.Sprintf("tf-testacc-sgrules%d", rand)
+	testAccConfig := resourceTestAccConfigFunc(resourceId, name, testAccSecurityGroupRulesBasicDependence)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"security_group_id": "${apsarastack_security_group.default.id}",
+					"ingress": []map[string]interface{}{
+						{
+							"ip_protocol": "tcp",
+							"port_range":  "22/22",
+							"cidr_ip":     "10.0.0.0/8",
+							"description": name,
+						},
+						{
+							"ip_protocol": "icmp",
+							"port_range":  "-1/-1",
+							"cidr_ip":     "0.0.0.0/0",
+						},
+					},
+					"egress": []map[string]interface{}{
+						{
+							"ip_protocol": "all",
+							"port_range":  "-1/-1",
+							"cidr_ip":     "0.0.0.0/0",
+						},
+					},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"ingress.#": "2",
+						"egress.#":  "1",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"ingress": []map[string]interface{}{
+						{
+							"ip_protocol": "tcp",
+							"port_range":  "22/22",
+							"cidr_ip":     "10.0.0.0/8",
+							"description": name + "_update",
+						},
+						{
+							"ip_protocol": "tcp",
+							"port_range":  "443/443",
+							"cidr_ip":     "0.0.0.0/0",
+							"priority":    "10",
+							"policy":      "drop",
+						},
+					},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"ingress.#": "2",
+					}),
+				),
+			},
+			{
+				Config: testAccConfig(map[string]interface{}{
+					"ingress": REMOVEKEY,
+					"egress":  REMOVEKEY,
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"ingress.#": "0",
+						"egress.#":  "0",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccSecurityGroupRulesBasicDependence(name string) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "%s"
+}
+resource "apsarastack_vpc" "default" {
+  name       = var.name
+  cidr_block = "172.16.0.0/16"
+}
+resource "apsarastack_security_group" "default" {
+  name   = var.name
+  vpc_id = apsarastack_vpc.default.id
+}
+`, name)
+}
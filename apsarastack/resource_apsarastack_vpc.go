@@ -69,6 +69,11 @@ func resourceApsaraStackVpc() *schema.Resource {
 				Computed: true,
 			},
 			"tags": tagsSchema(),
+			"resource_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -135,8 +140,6 @@ func resourceApsaraStackVpcCreate(d *schema.ResourceData, meta interface{}) erro
 		if !assoresponse.IsSuccess() {
 			return WrapErrorf(err, DefaultErrorMsg, "apsarastack_vpc", assorequest.GetActionName(), ApsaraStackSdkGoERROR)
 		}
-
-		return nil
 	}
 	return resourceApsaraStackVpcUpdate(d, meta)
 }
@@ -160,9 +163,12 @@ func resourceApsaraStackVpcRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("name", object.VpcName)
 	d.Set("description", object.Description)
 	d.Set("router_id", object.VRouterId)
-	if tag := object.Tags.Tag; tag != nil {
-		d.Set("tags", vpcService.tagToMap(tag))
+	d.Set("resource_group_id", object.ResourceGroupId)
+	tags, err := vpcService.DescribeTags(d.Id(), nil, TagResourceVpc)
+	if err != nil {
+		return WrapError(err)
 	}
+	d.Set("tags", vpcService.tagsToMap(tags))
 	request := vpc.CreateDescribeRouteTablesRequest()
 	request.RegionId = client.RegionId
 	request.Headers = map[string]string{"RegionId": client.RegionId}
@@ -222,7 +228,7 @@ func resourceApsaraStackVpcUpdate(d *schema.ResourceData, meta interface{}) erro
 	client := meta.(*connectivity.ApsaraStackClient)
 	vpcService := VpcService{client}
 	if d.HasChange("tags") {
-		if err := vpcService.setInstanceTags(d, "vpc"); err != nil {
+		if err := vpcService.setInstanceTags(d, TagResourceVpc); err != nil {
 			return WrapError(err)
 		}
 	}
@@ -231,6 +237,10 @@ func resourceApsaraStackVpcUpdate(d *schema.ResourceData, meta interface{}) erro
 		return resourceApsaraStackVpcRead(d, meta)
 	}
 
+	if err := vpcService.setResourceGroup(d, "vpc"); err != nil {
+		return WrapError(err)
+	}
+
 	attributeUpdate := false
 	request := vpc.CreateModifyVpcAttributeRequest()
 	request.RegionId = client.RegionId
@@ -359,6 +369,10 @@ func buildApsaraStackVpcArgs(d *schema.ResourceData, meta interface{}) *vpc.Crea
 	if v := d.Get("description").(string); v != "" {
 		request.Description = v
 	}
+
+	if v := d.Get("resource_group_id").(string); v != "" {
+		request.ResourceGroupId = v
+	}
 	//request.ClientToken = buildClientToken(request.GetActionName())
 
 	return request
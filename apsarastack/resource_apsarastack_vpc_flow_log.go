@@ -0,0 +1,290 @@
+package apsarastack
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceApsaraStackVpcFlowLog() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackVpcFlowLogCreate,
+		Read:   resourceApsaraStackVpcFlowLogRead,
+		Update: resourceApsaraStackVpcFlowLogUpdate,
+		Delete: resourceApsaraStackVpcFlowLogDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"resource_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"VPC", "VSwitch", "NetworkInterface"}, false),
+			},
+			"traffic_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"All", "Allow", "Drop"}, false),
+			},
+			"project_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"log_store_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"flow_log_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(2, 128),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(2, 256),
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{string(Active), string(Inactive)}, false),
+			},
+		},
+	}
+}
+
+func resourceApsaraStackVpcFlowLogCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateCreateFlowLogRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.ResourceId = d.Get("resource_id").(string)
+	request.ResourceType = d.Get("resource_type").(string)
+	request.TrafficType = d.Get("traffic_type").(string)
+	request.ProjectName = d.Get("project_name").(string)
+	request.LogStoreName = d.Get("log_store_name").(string)
+	if v, ok := d.GetOk("flow_log_name"); ok {
+		request.FlowLogName = v.(string)
+	}
+	if v, ok := d.GetOk("description"); ok {
+		request.Description = v.(string)
+	}
+
+	wait := incrementalWait(1*time.Second, 1*time.Second)
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.CreateFlowLog(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"OperationConflict", "IncorrectStatus", Throttling}) {
+				wait()
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*vpc.CreateFlowLogResponse)
+		d.SetId(response.FlowLogId)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "apsarastack_vpc_flow_log", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{"Activating"}, []string{string(Active)}, d.Timeout(schema.TimeoutCreate), 3*time.Second, vpcService.VpcFlowLogStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	// A new flow log always starts delivering, so Inactive has to be applied afterwards.
+	if d.Get("status").(string) == string(Inactive) {
+		if err := setVpcFlowLogStatus(d, meta, string(Inactive)); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	return resourceApsaraStackVpcFlowLogRead(d, meta)
+}
+
+func resourceApsaraStackVpcFlowLogRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	object, err := vpcService.DescribeVpcFlowLog(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("resource_id", object.ResourceId)
+	d.Set("resource_type", object.ResourceType)
+	d.Set("traffic_type", object.TrafficType)
+	d.Set("project_name", object.ProjectName)
+	d.Set("log_store_name", object.LogStoreName)
+	d.Set("flow_log_name", object.FlowLogName)
+	d.Set("description", object.Description)
+	d.Set("status", object.Status)
+	return nil
+}
+
+func resourceApsaraStackVpcFlowLogUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	d.Partial(true)
+
+	if d.HasChange("flow_log_name") || d.HasChange("description") {
+		request := vpc.CreateModifyFlowLogAttributeRequest()
+		if strings.ToLower(client.Config.Protocol) == "https" {
+			request.Scheme = "https"
+		} else {
+			request.Scheme = "http"
+		}
+		request.RegionId = client.RegionId
+		request.Headers = map[string]string{"RegionId": client.RegionId}
+		request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+		request.FlowLogId = d.Id()
+		request.FlowLogName = d.Get("flow_log_name").(string)
+		request.Description = d.Get("description").(string)
+
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.ModifyFlowLogAttribute(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		d.SetPartial("flow_log_name")
+		d.SetPartial("description")
+	}
+
+	if d.HasChange("status") {
+		if err := setVpcFlowLogStatus(d, meta, d.Get("status").(string)); err != nil {
+			return WrapError(err)
+		}
+		d.SetPartial("status")
+	}
+
+	d.Partial(false)
+	return resourceApsaraStackVpcFlowLogRead(d, meta)
+}
+
+func resourceApsaraStackVpcFlowLogDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateDeleteFlowLogRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.FlowLogId = d.Id()
+
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeleteFlowLog(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"InvalidFlowLogId.NotFound"}) {
+				return nil
+			}
+			if IsExpectedErrors(err, []string{"OperationConflict", "IncorrectStatus", Throttling}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{string(Active), string(Inactive), "Activating", "Deleting"}, []string{}, d.Timeout(schema.TimeoutDelete), 3*time.Second, vpcService.VpcFlowLogStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
+
+func setVpcFlowLogStatus(d *schema.ResourceData, meta interface{}, status string) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+
+	if status == string(Active) {
+		request := vpc.CreateActiveFlowLogRequest()
+		if strings.ToLower(client.Config.Protocol) == "https" {
+			request.Scheme = "https"
+		} else {
+			request.Scheme = "http"
+		}
+		request.RegionId = client.RegionId
+		request.Headers = map[string]string{"RegionId": client.RegionId}
+		request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+		request.FlowLogId = d.Id()
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.ActiveFlowLog(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	} else {
+		request := vpc.CreateDeactiveFlowLogRequest()
+		if strings.ToLower(client.Config.Protocol) == "https" {
+			request.Scheme = "https"
+		} else {
+			request.Scheme = "http"
+		}
+		request.RegionId = client.RegionId
+		request.Headers = map[string]string{"RegionId": client.RegionId}
+		request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+		request.FlowLogId = d.Id()
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeactiveFlowLog(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	}
+
+	stateConf := BuildStateConf([]string{"Activating"}, []string{status}, d.Timeout(schema.TimeoutUpdate), 3*time.Second, vpcService.VpcFlowLogStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackVpcFlowLog_basic(t *testing.T) {
+	var v vpc.FlowLog
+	rand := acctest.RandIntRange(10000, 99999)
+	resourceId := "apsarastack_vpc_flow_log.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"resource_id":    CHECKSET,
+		"resource_type":  "VPC",
+		"traffic_type":   "All",
+		"project_name":   fmt.Sprintf("tf-testacc-flowlog-%d", rand),
+		"log_store_name": fmt.Sprintf("tf-testacc-flowlog-%d", rand),
+		"flow_log_name":  fmt.Sprintf("tf-testAccVpcFlowLog%d", rand),
+		"status":         "Active",
+	})
+	serviceFunc := func() interface{} {
+		return &VpcService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		// module name
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcFlowLogConfig(rand, fmt.Sprintf("tf-testAccVpcFlowLog%d", rand), "Active"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccVpcFlowLogConfig(rand, fmt.Sprintf("tf-testAccVpcFlowLog%d_change", rand), "Active"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"flow_log_name": fmt.Sprintf("tf-testAccVpcFlowLog%d_change", rand),
+					}),
+				),
+			},
+			{
+				Config: testAccVpcFlowLogConfig(rand, fmt.Sprintf("tf-testAccVpcFlowLog%d_change", rand), "Inactive"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"status": "Inactive",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccVpcFlowLogConfigDependence(rand int) string {
+	return fmt.Sprintf(`
+variable "name" {
+  default = "tf-testacc-flowlog-%d"
+}
+
+resource "apsarastack_vpc" "default" {
+  name = "${var.name}"
+  cidr_block = "172.16.0.0/12"
+}
+
+resource "apsarastack_log_project" "default" {
+  name = "${var.name}"
+  description = "tf unit test"
+}
+
+resource "apsarastack_log_store" "default" {
+  project = "${apsarastack_log_project.default.name}"
+  name = "${var.name}"
+}
+`, rand)
+}
+
+func testAccVpcFlowLogConfig(rand int, name, status string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "apsarastack_vpc_flow_log" "default" {
+  resource_id = "${apsarastack_vpc.default.id}"
+  resource_type = "VPC"
+  traffic_type = "All"
+  project_name = "${apsarastack_log_project.default.name}"
+  log_store_name = "${apsarastack_log_store.default.name}"
+  flow_log_name = "%s"
+  status = "%s"
+}
+`, testAccVpcFlowLogConfigDependence(rand), name, status)
+}
@@ -0,0 +1,537 @@
+package apsarastack
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceApsaraStackVpnConnection() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackVpnConnectionCreate,
+		Read:   resourceApsaraStackVpnConnectionRead,
+		Update: resourceApsaraStackVpnConnectionUpdate,
+		Delete: resourceApsaraStackVpnConnectionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"customer_gateway_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"vpn_gateway_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringLenBetween(2, 128),
+			},
+			"local_subnet": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDR,
+				},
+				MinItems: 1,
+				MaxItems: 10,
+			},
+			"remote_subnet": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDR,
+				},
+				MinItems: 1,
+				MaxItems: 10,
+			},
+			"effect_immediately": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"enable_dpd": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"enable_nat_traversal": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"ike_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"psk": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.StringLenBetween(1, 100),
+						},
+						"ike_version": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "ikev1",
+							ValidateFunc: validation.StringInSlice([]string{"ikev1", "ikev2"}, false),
+						},
+						"ike_mode": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "main",
+							ValidateFunc: validation.StringInSlice([]string{"main", "aggressive"}, false),
+						},
+						"ike_enc_alg": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "aes",
+							ValidateFunc: validation.StringInSlice([]string{"aes", "aes192", "aes256", "des", "3des"}, false),
+						},
+						"ike_auth_alg": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "sha1",
+							ValidateFunc: validation.StringInSlice([]string{"md5", "sha1", "sha256", "sha384", "sha512"}, false),
+						},
+						"ike_pfs": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "group2",
+							ValidateFunc: validation.StringInSlice([]string{"group1", "group2", "group5", "group14", "group24"}, false),
+						},
+						"ike_lifetime": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      86400,
+							ValidateFunc: validation.IntBetween(0, 86400),
+						},
+						"ike_local_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringLenBetween(1, 100),
+						},
+						"ike_remote_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringLenBetween(1, 100),
+						},
+					},
+				},
+			},
+			"ipsec_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ipsec_enc_alg": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "aes",
+							ValidateFunc: validation.StringInSlice([]string{"aes", "aes192", "aes256", "des", "3des"}, false),
+						},
+						"ipsec_auth_alg": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "sha1",
+							ValidateFunc: validation.StringInSlice([]string{"md5", "sha1", "sha256", "sha384", "sha512"}, false),
+						},
+						"ipsec_pfs": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "group2",
+							ValidateFunc: validation.StringInSlice([]string{"disabled", "group1", "group2", "group5", "group14", "group24"}, false),
+						},
+						"ipsec_lifetime": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      86400,
+							ValidateFunc: validation.IntBetween(0, 86400),
+						},
+					},
+				},
+			},
+			"health_check_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enable": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"dip": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsIPAddress,
+						},
+						"sip": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsIPAddress,
+						},
+						"interval": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      3,
+							ValidateFunc: validation.IntBetween(1, 60),
+						},
+						"retry": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      3,
+							ValidateFunc: validation.IntBetween(1, 10),
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceApsaraStackVpnConnectionCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	request := vpc.CreateCreateVpnConnectionRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.CustomerGatewayId = d.Get("customer_gateway_id").(string)
+	request.VpnGatewayId = d.Get("vpn_gateway_id").(string)
+	request.LocalSubnet = convertListToCommaSeparate(d.Get("local_subnet").(*schema.Set).List())
+	request.RemoteSubnet = convertListToCommaSeparate(d.Get("remote_subnet").(*schema.Set).List())
+	request.EffectImmediately = requests.NewBoolean(d.Get("effect_immediately").(bool))
+	request.EnableDpd = requests.NewBoolean(d.Get("enable_dpd").(bool))
+	request.EnableNatTraversal = requests.NewBoolean(d.Get("enable_nat_traversal").(bool))
+	if v, ok := d.GetOk("name"); ok {
+		request.Name = v.(string)
+	}
+
+	ikeConfig, err := buildApsaraStackVpnIkeConfig(d)
+	if err != nil {
+		return WrapError(err)
+	}
+	request.IkeConfig = ikeConfig
+	ipsecConfig, err := buildApsaraStackVpnIpsecConfig(d)
+	if err != nil {
+		return WrapError(err)
+	}
+	request.IpsecConfig = ipsecConfig
+	healthCheckConfig, err := buildApsaraStackVpnHealthCheckConfig(d)
+	if err != nil {
+		return WrapError(err)
+	}
+	request.HealthCheckConfig = healthCheckConfig
+
+	wait := incrementalWait(3*time.Second, 3*time.Second)
+	err = resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		request.ClientToken = buildClientToken(request.GetActionName())
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.CreateVpnConnection(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"VpnGateway.Configuring", "OperationConflict", Throttling}) {
+				wait()
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*vpc.CreateVpnConnectionResponse)
+		d.SetId(response.VpnConnectionId)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "apsarastack_vpn_connection", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	return resourceApsaraStackVpnConnectionRead(d, meta)
+}
+
+func resourceApsaraStackVpnConnectionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	object, err := vpcService.DescribeVpnConnection(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("customer_gateway_id", object.CustomerGatewayId)
+	d.Set("vpn_gateway_id", object.VpnGatewayId)
+	d.Set("name", object.Name)
+	d.Set("local_subnet", strings.Split(object.LocalSubnet, ","))
+	d.Set("remote_subnet", strings.Split(object.RemoteSubnet, ","))
+	d.Set("effect_immediately", object.EffectImmediately)
+	d.Set("enable_dpd", object.EnableDpd)
+	d.Set("enable_nat_traversal", object.EnableNatTraversal)
+	d.Set("status", object.Status)
+
+	if err := d.Set("ike_config", flattenApsaraStackVpnIkeConfig(object.IkeConfig)); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("ipsec_config", flattenApsaraStackVpnIpsecConfig(object.IpsecConfig)); err != nil {
+		return WrapError(err)
+	}
+	healthCheck := []map[string]interface{}{
+		{
+			"enable":   object.VcoHealthCheck.Enable == "true" || object.VcoHealthCheck.Enable == "enable",
+			"dip":      object.VcoHealthCheck.Dip,
+			"sip":      object.VcoHealthCheck.Sip,
+			"interval": object.VcoHealthCheck.Interval,
+			"retry":    object.VcoHealthCheck.Retry,
+			"status":   object.VcoHealthCheck.Status,
+		},
+	}
+	if err := d.Set("health_check_config", healthCheck); err != nil {
+		return WrapError(err)
+	}
+	return nil
+}
+
+func resourceApsaraStackVpnConnectionUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	request := vpc.CreateModifyVpnConnectionAttributeRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.VpnConnectionId = d.Id()
+	request.Name = d.Get("name").(string)
+	request.LocalSubnet = convertListToCommaSeparate(d.Get("local_subnet").(*schema.Set).List())
+	request.RemoteSubnet = convertListToCommaSeparate(d.Get("remote_subnet").(*schema.Set).List())
+	request.EffectImmediately = requests.NewBoolean(d.Get("effect_immediately").(bool))
+	request.EnableDpd = requests.NewBoolean(d.Get("enable_dpd").(bool))
+	request.EnableNatTraversal = requests.NewBoolean(d.Get("enable_nat_traversal").(bool))
+
+	// ModifyVpnConnectionAttribute resets any configuration block that is left out, so all of them are always sent.
+	ikeConfig, err := buildApsaraStackVpnIkeConfig(d)
+	if err != nil {
+		return WrapError(err)
+	}
+	request.IkeConfig = ikeConfig
+	ipsecConfig, err := buildApsaraStackVpnIpsecConfig(d)
+	if err != nil {
+		return WrapError(err)
+	}
+	request.IpsecConfig = ipsecConfig
+	healthCheckConfig, err := buildApsaraStackVpnHealthCheckConfig(d)
+	if err != nil {
+		return WrapError(err)
+	}
+	request.HealthCheckConfig = healthCheckConfig
+
+	wait := incrementalWait(3*time.Second, 3*time.Second)
+	err = resource.Retry(d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
+		request.ClientToken = buildClientToken(request.GetActionName())
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.ModifyVpnConnectionAttribute(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"VpnGateway.Configuring", "OperationConflict", Throttling}) {
+				wait()
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	return resourceApsaraStackVpnConnectionRead(d, meta)
+}
+
+func resourceApsaraStackVpnConnectionDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateDeleteVpnConnectionRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.VpnConnectionId = d.Id()
+
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		request.ClientToken = buildClientToken(request.GetActionName())
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeleteVpnConnection(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"InvalidVpnConnectionInstanceId.NotFound", "Forbidden"}) {
+				return nil
+			}
+			if IsExpectedErrors(err, []string{"VpnGateway.Configuring", "OperationConflict", Throttling}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	return WrapError(resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		if _, err := vpcService.DescribeVpnConnection(d.Id()); err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			return resource.NonRetryableError(err)
+		}
+		return resource.RetryableError(Error("the VPN connection %s is still being deleted", d.Id()))
+	}))
+}
+
+func buildApsaraStackVpnIkeConfig(d *schema.ResourceData) (string, error) {
+	config := map[string]interface{}{
+		"IkeVersion":  "ikev1",
+		"IkeMode":     "main",
+		"IkeEncAlg":   "aes",
+		"IkeAuthAlg":  "sha1",
+		"IkePfs":      "group2",
+		"IkeLifetime": 86400,
+	}
+	if v, ok := d.GetOk("ike_config"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		ike := v.([]interface{})[0].(map[string]interface{})
+		config["IkeVersion"] = ike["ike_version"]
+		config["IkeMode"] = ike["ike_mode"]
+		config["IkeEncAlg"] = ike["ike_enc_alg"]
+		config["IkeAuthAlg"] = ike["ike_auth_alg"]
+		config["IkePfs"] = ike["ike_pfs"]
+		config["IkeLifetime"] = ike["ike_lifetime"]
+		if psk, ok := ike["psk"]; ok && psk.(string) != "" {
+			config["Psk"] = psk
+		}
+		if localId, ok := ike["ike_local_id"]; ok && localId.(string) != "" {
+			config["LocalId"] = localId
+		}
+		if remoteId, ok := ike["ike_remote_id"]; ok && remoteId.(string) != "" {
+			config["RemoteId"] = remoteId
+		}
+	}
+	return convertMaptoJsonString(config)
+}
+
+func buildApsaraStackVpnIpsecConfig(d *schema.ResourceData) (string, error) {
+	config := map[string]interface{}{
+		"IpsecEncAlg":   "aes",
+		"IpsecAuthAlg":  "sha1",
+		"IpsecPfs":      "group2",
+		"IpsecLifetime": 86400,
+	}
+	if v, ok := d.GetOk("ipsec_config"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		ipsec := v.([]interface{})[0].(map[string]interface{})
+		config["IpsecEncAlg"] = ipsec["ipsec_enc_alg"]
+		config["IpsecAuthAlg"] = ipsec["ipsec_auth_alg"]
+		config["IpsecPfs"] = ipsec["ipsec_pfs"]
+		config["IpsecLifetime"] = ipsec["ipsec_lifetime"]
+	}
+	return convertMaptoJsonString(config)
+}
+
+func buildApsaraStackVpnHealthCheckConfig(d *schema.ResourceData) (string, error) {
+	v, ok := d.GetOk("health_check_config")
+	if !ok || len(v.([]interface{})) < 1 || v.([]interface{})[0] == nil {
+		return "", nil
+	}
+	healthCheck := v.([]interface{})[0].(map[string]interface{})
+	config := map[string]interface{}{
+		"enable":   healthCheck["enable"],
+		"interval": healthCheck["interval"],
+		"retry":    healthCheck["retry"],
+	}
+	if dip, ok := healthCheck["dip"]; ok && dip.(string) != "" {
+		config["dip"] = dip
+	}
+	if sip, ok := healthCheck["sip"]; ok && sip.(string) != "" {
+		config["sip"] = sip
+	}
+	return convertMaptoJsonString(config)
+}
+
+func flattenApsaraStackVpnIkeConfig(config vpc.IkeConfig) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"psk":           config.Psk,
+			"ike_version":   config.IkeVersion,
+			"ike_mode":      config.IkeMode,
+			"ike_enc_alg":   config.IkeEncAlg,
+			"ike_auth_alg":  config.IkeAuthAlg,
+			"ike_pfs":       config.IkePfs,
+			"ike_lifetime":  int(config.IkeLifetime),
+			"ike_local_id":  config.LocalId,
+			"ike_remote_id": config.RemoteId,
+		},
+	}
+}
+
+func flattenApsaraStackVpnIpsecConfig(config vpc.IpsecConfig) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"ipsec_enc_alg":  config.IpsecEncAlg,
+			"ipsec_auth_alg": config.IpsecAuthAlg,
+			"ipsec_pfs":      config.IpsecPfs,
+			"ipsec_lifetime": int(config.IpsecLifetime),
+		},
+	}
+}
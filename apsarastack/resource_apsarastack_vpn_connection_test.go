@@ -0,0 +1,128 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackVpnConnection_basic(t *testing.T) {
+	var v vpc.DescribeVpnConnectionResponse
+	rand := acctest.RandIntRange(1000, 9999)
+	resourceId := "apsarastack_vpn_connection.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"name":                          fmt.Sprintf("tf-testAccVpnConnection%d", rand),
+		"vpn_gateway_id":                CHECKSET,
+		"customer_gateway_id":           CHECKSET,
+		"local_subnet.#":                "1",
+		"remote_subnet.#":               "1",
+		"effect_immediately":            "true",
+		"ike_config.#":                  "1",
+		"ike_config.0.ike_version":      "ikev1",
+		"ike_config.0.ike_enc_alg":      "aes",
+		"ike_config.0.ike_auth_alg":     "sha1",
+		"ike_config.0.ike_pfs":          "group2",
+		"ike_config.0.ike_lifetime":     "86400",
+		"ipsec_config.#":                "1",
+		"ipsec_config.0.ipsec_enc_alg":  "aes",
+		"ipsec_config.0.ipsec_auth_alg": "sha1",
+		"ipsec_config.0.ipsec_pfs":      "group2",
+		"ipsec_config.0.ipsec_lifetime": "86400",
+	})
+	serviceFunc := func() interface{} {
+		return &VpcService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		// module name
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpnConnectionConfig(rand, "aes", "group2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccVpnConnectionConfig(rand, "aes256", "group5"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"ike_config.0.ike_enc_alg":       "aes256",
+						"ike_config.0.ike_pfs":           "group5",
+						"ipsec_config.0.ipsec_enc_alg":   "aes256",
+						"ipsec_config.0.ipsec_pfs":       "group5",
+						"health_check_config.0.enable":   "true",
+						"health_check_config.0.dip":      "192.168.1.1",
+						"health_check_config.0.sip":      "172.16.0.1",
+						"health_check_config.0.retry":    "3",
+						"health_check_config.0.interval": "3",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccVpnConnectionConfigDependence(rand int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "apsarastack_vpn_gateway" "default" {
+  name = "${var.name}"
+  vpc_id = "${apsarastack_vpc.default.id}"
+  vswitch_id = "${apsarastack_vswitch.default.id}"
+  bandwidth = 10
+}
+
+resource "apsarastack_vpn_customer_gateway" "default" {
+  name = "${var.name}"
+  ip_address = "42.104.22.210"
+}
+`, testAccVpnGatewayConfigDependence(rand))
+}
+
+func testAccVpnConnectionConfig(rand int, encAlg, pfs string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "apsarastack_vpn_connection" "default" {
+  name = "tf-testAccVpnConnection%d"
+  vpn_gateway_id = "${apsarastack_vpn_gateway.default.id}"
+  customer_gateway_id = "${apsarastack_vpn_customer_gateway.default.id}"
+  local_subnet = ["172.16.0.0/24"]
+  remote_subnet = ["192.168.1.0/24"]
+  effect_immediately = true
+  ike_config {
+    ike_enc_alg = "%s"
+    ike_pfs = "%s"
+    psk = "tf-testvpn1"
+  }
+  ipsec_config {
+    ipsec_enc_alg = "%s"
+    ipsec_pfs = "%s"
+  }
+  health_check_config {
+    enable = true
+    dip = "192.168.1.1"
+    sip = "172.16.0.1"
+  }
+}
+`, testAccVpnConnectionConfigDependence(rand), rand, encAlg, pfs, encAlg, pfs)
+}
@@ -0,0 +1,193 @@
+package apsarastack
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceApsaraStackVpnCustomerGateway() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackVpnCustomerGatewayCreate,
+		Read:   resourceApsaraStackVpnCustomerGatewayRead,
+		Update: resourceApsaraStackVpnCustomerGatewayUpdate,
+		Delete: resourceApsaraStackVpnCustomerGatewayDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"ip_address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"asn": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringLenBetween(2, 128),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(2, 256),
+			},
+		},
+	}
+}
+
+func resourceApsaraStackVpnCustomerGatewayCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	request := vpc.CreateCreateCustomerGatewayRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.IpAddress = d.Get("ip_address").(string)
+	if v, ok := d.GetOk("asn"); ok {
+		request.Asn = strconv.Itoa(v.(int))
+	}
+	if v, ok := d.GetOk("name"); ok {
+		request.Name = v.(string)
+	}
+	if v, ok := d.GetOk("description"); ok {
+		request.Description = v.(string)
+	}
+
+	wait := incrementalWait(1*time.Second, 1*time.Second)
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		request.ClientToken = buildClientToken(request.GetActionName())
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.CreateCustomerGateway(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"OperationConflict", Throttling}) {
+				wait()
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*vpc.CreateCustomerGatewayResponse)
+		d.SetId(response.CustomerGatewayId)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "apsarastack_vpn_customer_gateway", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	return resourceApsaraStackVpnCustomerGatewayRead(d, meta)
+}
+
+func resourceApsaraStackVpnCustomerGatewayRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	object, err := vpcService.DescribeVpnCustomerGateway(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("ip_address", object.IpAddress)
+	d.Set("name", object.Name)
+	d.Set("description", object.Description)
+	if object.Asn > 0 {
+		d.Set("asn", int(object.Asn))
+	}
+	return nil
+}
+
+func resourceApsaraStackVpnCustomerGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	if !d.HasChange("name") && !d.HasChange("description") {
+		return resourceApsaraStackVpnCustomerGatewayRead(d, meta)
+	}
+
+	request := vpc.CreateModifyCustomerGatewayAttributeRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.CustomerGatewayId = d.Id()
+	request.Name = d.Get("name").(string)
+	request.Description = d.Get("description").(string)
+
+	raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.ModifyCustomerGatewayAttribute(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+
+	return resourceApsaraStackVpnCustomerGatewayRead(d, meta)
+}
+
+func resourceApsaraStackVpnCustomerGatewayDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	request := vpc.CreateDeleteCustomerGatewayRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.CustomerGatewayId = d.Id()
+
+	// A customer gateway cannot be removed while a VPN connection still references it.
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		request.ClientToken = buildClientToken(request.GetActionName())
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeleteCustomerGateway(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"InvalidCustomerGatewayInstanceId.NotFound", "Forbidden"}) {
+				return nil
+			}
+			if IsExpectedErrors(err, []string{"DependencyViolation.VpnConnection", "OperationConflict", Throttling}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackVpnCustomerGateway_basic(t *testing.T) {
+	var v vpc.DescribeCustomerGatewayResponse
+	rand := acctest.RandIntRange(1000, 9999)
+	resourceId := "apsarastack_vpn_customer_gateway.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"name":        fmt.Sprintf("tf-testAccVpnCustomerGateway%d", rand),
+		"ip_address":  "42.104.22.210",
+		"description": "",
+	})
+	serviceFunc := func() interface{} {
+		return &VpcService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		// module name
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpnCustomerGatewayConfig(fmt.Sprintf("tf-testAccVpnCustomerGateway%d", rand), ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccVpnCustomerGatewayConfig(fmt.Sprintf("tf-testAccVpnCustomerGateway%d_change", rand), "tf-testAccVpnCustomerGatewayDescription"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"name":        fmt.Sprintf("tf-testAccVpnCustomerGateway%d_change", rand),
+						"description": "tf-testAccVpnCustomerGatewayDescription",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccVpnCustomerGatewayConfig(name, description string) string {
+	descriptionConfig := ""
+	if description != "" {
+		descriptionConfig = fmt.Sprintf(`description = "%s"`, description)
+	}
+	return fmt.Sprintf(`
+resource "apsarastack_vpn_customer_gateway" "default" {
+  name = "%s"
+  ip_address = "42.104.22.210"
+  %s
+}
+`, name, descriptionConfig)
+}
@@ -0,0 +1,291 @@
+package apsarastack
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceApsaraStackVpnGateway() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackVpnGatewayCreate,
+		Read:   resourceApsaraStackVpnGatewayRead,
+		Update: resourceApsaraStackVpnGatewayUpdate,
+		Delete: resourceApsaraStackVpnGatewayDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringLenBetween(2, 128),
+			},
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"vswitch_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"bandwidth": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntInSlice([]int{5, 10, 20, 50, 100, 200, 500, 1000}),
+			},
+			"enable_ipsec": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+			"enable_ssl": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+			"ssl_connections": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          5,
+				ForceNew:         true,
+				ValidateFunc:     validation.IntInSlice([]int{5, 10, 20, 50, 100, 200, 500, 1000}),
+				DiffSuppressFunc: vpnSslConnectionsDiffSuppressFunc,
+			},
+			"instance_charge_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      PostPaid,
+				ValidateFunc: validation.StringInSlice([]string{string(PrePaid), string(PostPaid)}, false),
+			},
+			"period": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          1,
+				ValidateFunc:     validation.IntInSlice([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 12, 24, 36}),
+				DiffSuppressFunc: PostPaidDiffSuppressFunc,
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(2, 256),
+			},
+			"internet_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"business_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceApsaraStackVpnGatewayCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateCreateVpnGatewayRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.VpcId = d.Get("vpc_id").(string)
+	if v, ok := d.GetOk("vswitch_id"); ok {
+		request.VSwitchId = v.(string)
+	}
+	if v, ok := d.GetOk("name"); ok {
+		request.Name = v.(string)
+	}
+	request.Bandwidth = requests.NewInteger(d.Get("bandwidth").(int))
+	request.EnableIpsec = requests.NewBoolean(d.Get("enable_ipsec").(bool))
+	request.EnableSsl = requests.NewBoolean(d.Get("enable_ssl").(bool))
+	if d.Get("enable_ssl").(bool) {
+		request.SslConnections = requests.NewInteger(d.Get("ssl_connections").(int))
+	}
+	// The API expects PREPAY/POSTPAY rather than the PrePaid/PostPaid values used across the provider.
+	if d.Get("instance_charge_type").(string) == string(PrePaid) {
+		request.InstanceChargeType = "PREPAY"
+		request.Period = requests.NewInteger(d.Get("period").(int))
+	} else {
+		request.InstanceChargeType = "POSTPAY"
+	}
+	request.AutoPay = requests.NewBoolean(true)
+
+	wait := incrementalWait(1*time.Second, 1*time.Second)
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		request.ClientToken = buildClientToken(request.GetActionName())
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.CreateVpnGateway(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"VpnGateway.Configuring", "OperationConflict", Throttling}) {
+				wait()
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*vpc.CreateVpnGatewayResponse)
+		d.SetId(response.VpnGatewayId)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "apsarastack_vpn_gateway", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{"init", "provisioning"}, []string{"active"}, d.Timeout(schema.TimeoutCreate), 5*time.Second, vpcService.VpnGatewayStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceApsaraStackVpnGatewayUpdate(d, meta)
+}
+
+func resourceApsaraStackVpnGatewayRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	object, err := vpcService.DescribeVpnGateway(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("name", object.Name)
+	d.Set("description", object.Description)
+	d.Set("vpc_id", object.VpcId)
+	d.Set("vswitch_id", object.VSwitchId)
+	d.Set("internet_ip", object.InternetIp)
+	d.Set("business_status", object.BusinessStatus)
+	d.Set("status", object.Status)
+	d.Set("enable_ipsec", strings.ToLower(object.IpsecVpn) == "enable")
+	d.Set("enable_ssl", strings.ToLower(object.SslVpn) == "enable")
+	if strings.ToLower(object.SslVpn) == "enable" {
+		d.Set("ssl_connections", int(object.SslMaxConnections))
+	}
+
+	// Spec is returned as "<bandwidth>M".
+	if bandwidth, err := strconv.Atoi(strings.TrimSuffix(object.Spec, "M")); err == nil {
+		d.Set("bandwidth", bandwidth)
+	}
+	if strings.ToLower(object.ChargeType) == "prepay" || object.ChargeType == string(PrePaid) {
+		d.Set("instance_charge_type", string(PrePaid))
+	} else {
+		d.Set("instance_charge_type", string(PostPaid))
+	}
+	return nil
+}
+
+func resourceApsaraStackVpnGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+
+	// CreateVpnGateway does not accept a description, so a new gateway also goes through ModifyVpnGatewayAttribute.
+	if !d.HasChange("name") && !d.HasChange("description") {
+		return resourceApsaraStackVpnGatewayRead(d, meta)
+	}
+
+	request := vpc.CreateModifyVpnGatewayAttributeRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.VpnGatewayId = d.Id()
+	request.Name = d.Get("name").(string)
+	request.Description = d.Get("description").(string)
+
+	raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.ModifyVpnGatewayAttribute(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+
+	return resourceApsaraStackVpnGatewayRead(d, meta)
+}
+
+func resourceApsaraStackVpnGatewayDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+
+	if d.Get("instance_charge_type").(string) == string(PrePaid) {
+		log.Printf("[WARN] Cannot destroy Subscription VPN gateway %s. Terraform will remove this resource from the state file, however resources may remain.", d.Id())
+		return nil
+	}
+
+	request := vpc.CreateDeleteVpnGatewayRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.VpnGatewayId = d.Id()
+
+	// Connections and route entries release the gateway asynchronously.
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		request.ClientToken = buildClientToken(request.GetActionName())
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeleteVpnGateway(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"InvalidVpnGatewayInstanceId.NotFound", "Forbidden"}) {
+				return nil
+			}
+			if IsExpectedErrors(err, []string{"VpnGateway.Configuring", "IncorrectStatus.VpnGateway", "OperationConflict", Throttling}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{"active", "updating", "deleting"}, []string{}, d.Timeout(schema.TimeoutDelete), 5*time.Second, vpcService.VpnGatewayStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
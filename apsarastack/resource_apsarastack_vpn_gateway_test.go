@@ -0,0 +1,110 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackVpnGateway_basic(t *testing.T) {
+	var v vpc.DescribeVpnGatewayResponse
+	rand := acctest.RandIntRange(1000, 9999)
+	resourceId := "apsarastack_vpn_gateway.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"name":                 fmt.Sprintf("tf-testAccVpnGateway%d", rand),
+		"bandwidth":            "10",
+		"enable_ipsec":         "true",
+		"enable_ssl":           "false",
+		"instance_charge_type": "PostPaid",
+		"vpc_id":               CHECKSET,
+		"vswitch_id":           CHECKSET,
+		"internet_ip":          CHECKSET,
+		"status":               "active",
+		"business_status":      "Normal",
+	})
+	serviceFunc := func() interface{} {
+		return &VpcService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		// module name
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpnGatewayConfig(rand, fmt.Sprintf("tf-testAccVpnGateway%d", rand), ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:            resourceId,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"period"},
+			},
+			{
+				Config: testAccVpnGatewayConfig(rand, fmt.Sprintf("tf-testAccVpnGateway%d_change", rand), "tf-testAccVpnGatewayDescription"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"name":        fmt.Sprintf("tf-testAccVpnGateway%d_change", rand),
+						"description": "tf-testAccVpnGatewayDescription",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccVpnGatewayConfigDependence(rand int) string {
+	return fmt.Sprintf(`
+data "apsarastack_zones" "default" {
+  available_resource_creation = "VSwitch"
+}
+
+variable "name" {
+  default = "tf-testAccVpnGateway%d"
+}
+
+resource "apsarastack_vpc" "default" {
+  name = "${var.name}"
+  cidr_block = "172.16.0.0/12"
+}
+
+resource "apsarastack_vswitch" "default" {
+  vpc_id = "${apsarastack_vpc.default.id}"
+  cidr_block = "172.16.0.0/21"
+  availability_zone = "${data.apsarastack_zones.default.zones.0.id}"
+  name = "${var.name}"
+}
+`, rand)
+}
+
+func testAccVpnGatewayConfig(rand int, name, description string) string {
+	descriptionConfig := ""
+	if description != "" {
+		descriptionConfig = fmt.Sprintf(`description = "%s"`, description)
+	}
+	return fmt.Sprintf(`
+%s
+
+resource "apsarastack_vpn_gateway" "default" {
+  name = "%s"
+  vpc_id = "${apsarastack_vpc.default.id}"
+  vswitch_id = "${apsarastack_vswitch.default.id}"
+  bandwidth = 10
+  %s
+}
+`, testAccVpnGatewayConfigDependence(rand), name, descriptionConfig)
+}
@@ -0,0 +1,265 @@
+package apsarastack
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceApsaraStackVpnRouteEntry() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceApsaraStackVpnRouteEntryCreate,
+		Read:   resourceApsaraStackVpnRouteEntryRead,
+		Update: resourceApsaraStackVpnRouteEntryUpdate,
+		Delete: resourceApsaraStackVpnRouteEntryDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"vpn_gateway_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"route_dest": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsCIDR,
+			},
+			"next_hop": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"weight": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntInSlice([]int{0, 100}),
+			},
+			"publish_vpc": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceApsaraStackVpnRouteEntryCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateCreateVpnRouteEntryRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.VpnGatewayId = d.Get("vpn_gateway_id").(string)
+	request.RouteDest = d.Get("route_dest").(string)
+	request.NextHop = d.Get("next_hop").(string)
+	request.Weight = requests.NewInteger(d.Get("weight").(int))
+	request.PublishVpc = requests.NewBoolean(d.Get("publish_vpc").(bool))
+
+	wait := incrementalWait(3*time.Second, 3*time.Second)
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		request.ClientToken = buildClientToken(request.GetActionName())
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.CreateVpnRouteEntry(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"VpnGateway.Configuring", "OperationConflict", Throttling}) {
+				wait()
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "apsarastack_vpn_route_entry", request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	d.SetId(fmt.Sprintf("%s%s%s%s%s", request.VpnGatewayId, COLON_SEPARATED, request.NextHop, COLON_SEPARATED, request.RouteDest))
+
+	stateConf := BuildStateConf([]string{}, []string{"normal", "published"}, d.Timeout(schema.TimeoutCreate), 3*time.Second, vpcService.VpnRouteEntryStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+
+	return resourceApsaraStackVpnRouteEntryRead(d, meta)
+}
+
+func resourceApsaraStackVpnRouteEntryRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	parts, err := ParseResourceId(d.Id(), 3)
+	if err != nil {
+		return WrapError(err)
+	}
+	object, err := vpcService.DescribeVpnRouteEntry(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("vpn_gateway_id", parts[0])
+	d.Set("next_hop", object.NextHop)
+	d.Set("route_dest", object.RouteDest)
+	d.Set("weight", object.Weight)
+	d.Set("publish_vpc", object.State == "published")
+	d.Set("status", object.State)
+	return nil
+}
+
+func resourceApsaraStackVpnRouteEntryUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	parts, err := ParseResourceId(d.Id(), 3)
+	if err != nil {
+		return WrapError(err)
+	}
+	d.Partial(true)
+
+	if d.HasChange("publish_vpc") {
+		request := vpc.CreatePublishVpnRouteEntryRequest()
+		if strings.ToLower(client.Config.Protocol) == "https" {
+			request.Scheme = "https"
+		} else {
+			request.Scheme = "http"
+		}
+		request.RegionId = client.RegionId
+		request.Headers = map[string]string{"RegionId": client.RegionId}
+		request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+		request.VpnGatewayId = parts[0]
+		request.NextHop = parts[1]
+		request.RouteDest = parts[2]
+		request.RouteType = "dbr"
+		request.PublishVpc = requests.NewBoolean(d.Get("publish_vpc").(bool))
+		request.ClientToken = buildClientToken(request.GetActionName())
+
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.PublishVpnRouteEntry(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+
+		target := "normal"
+		if d.Get("publish_vpc").(bool) {
+			target = "published"
+		}
+		stateConf := BuildStateConf([]string{}, []string{target}, d.Timeout(schema.TimeoutUpdate), 3*time.Second, vpcService.VpnRouteEntryStateRefreshFunc(d.Id(), []string{}))
+		if _, err := stateConf.WaitForState(); err != nil {
+			return WrapErrorf(err, IdMsg, d.Id())
+		}
+		d.SetPartial("publish_vpc")
+	}
+
+	if d.HasChange("weight") {
+		oldWeight, newWeight := d.GetChange("weight")
+		request := vpc.CreateModifyVpnRouteEntryWeightRequest()
+		if strings.ToLower(client.Config.Protocol) == "https" {
+			request.Scheme = "https"
+		} else {
+			request.Scheme = "http"
+		}
+		request.RegionId = client.RegionId
+		request.Headers = map[string]string{"RegionId": client.RegionId}
+		request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+		request.VpnGatewayId = parts[0]
+		request.NextHop = parts[1]
+		request.RouteDest = parts[2]
+		request.Weight = requests.NewInteger(oldWeight.(int))
+		request.NewWeight = requests.NewInteger(newWeight.(int))
+		request.ClientToken = buildClientToken(request.GetActionName())
+
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.ModifyVpnRouteEntryWeight(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		d.SetPartial("weight")
+	}
+
+	d.Partial(false)
+	return resourceApsaraStackVpnRouteEntryRead(d, meta)
+}
+
+func resourceApsaraStackVpnRouteEntryDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	parts, err := ParseResourceId(d.Id(), 3)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	request := vpc.CreateDeleteVpnRouteEntryRequest()
+	if strings.ToLower(client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = client.RegionId
+	request.Headers = map[string]string{"RegionId": client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": client.SecretKey, "Product": "vpc", "Department": client.Department, "ResourceGroup": client.ResourceGroup}
+	request.VpnGatewayId = parts[0]
+	request.NextHop = parts[1]
+	request.RouteDest = parts[2]
+	request.Weight = requests.NewInteger(d.Get("weight").(int))
+
+	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		request.ClientToken = buildClientToken(request.GetActionName())
+		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeleteVpnRouteEntry(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"InvalidVpnGatewayInstanceId.NotFound", "VpnRouteEntry.NotFound", "Forbidden"}) {
+				return nil
+			}
+			if IsExpectedErrors(err, []string{"VpnGateway.Configuring", "OperationConflict", Throttling}) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+
+	stateConf := BuildStateConf([]string{"normal", "published", "deleting"}, []string{}, d.Timeout(schema.TimeoutDelete), 3*time.Second, vpcService.VpnRouteEntryStateRefreshFunc(d.Id(), []string{}))
+	if _, err := stateConf.WaitForState(); err != nil {
+		return WrapErrorf(err, IdMsg, d.Id())
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package apsarastack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApsaraStackVpnRouteEntry_basic(t *testing.T) {
+	var v vpc.VpnRouteEntry
+	rand := acctest.RandIntRange(1000, 9999)
+	resourceId := "apsarastack_vpn_route_entry.default"
+	ra := resourceAttrInit(resourceId, map[string]string{
+		"vpn_gateway_id": CHECKSET,
+		"next_hop":       CHECKSET,
+		"route_dest":     "10.0.0.0/24",
+		"weight":         "100",
+		"publish_vpc":    "false",
+		"status":         "normal",
+	})
+	serviceFunc := func() interface{} {
+		return &VpcService{testAccProvider.Meta().(*connectivity.ApsaraStackClient)}
+	}
+	rc := resourceCheckInit(resourceId, &v, serviceFunc)
+	rac := resourceAttrCheckInit(rc, ra)
+	testAccCheck := rac.resourceAttrMapUpdateSet()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		// module name
+		IDRefreshName: resourceId,
+		Providers:     testAccProviders,
+		CheckDestroy:  rac.checkResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpnRouteEntryConfig(rand, 100, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(nil),
+				),
+			},
+			{
+				ResourceName:      resourceId,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccVpnRouteEntryConfig(rand, 100, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"publish_vpc": "true",
+						"status":      "published",
+					}),
+				),
+			},
+			{
+				Config: testAccVpnRouteEntryConfig(rand, 0, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"weight": "0",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccVpnRouteEntryConfig(rand, weight int, publishVpc bool) string {
+	return fmt.Sprintf(`
+%s
+
+resource "apsarastack_vpn_connection" "default" {
+  name = "${var.name}"
+  vpn_gateway_id = "${apsarastack_vpn_gateway.default.id}"
+  customer_gateway_id = "${apsarastack_vpn_customer_gateway.default.id}"
+  local_subnet = ["0.0.0.0/0"]
+  remote_subnet = ["0.0.0.0/0"]
+  effect_immediately = true
+}
+
+resource "apsarastack_vpn_route_entry" "default" {
+  vpn_gateway_id = "${apsarastack_vpn_gateway.default.id}"
+  route_dest = "10.0.0.0/24"
+  next_hop = "${apsarastack_vpn_connection.default.id}"
+  weight = %d
+  publish_vpc = %t
+}
+`, testAccVpnConnectionConfigDependence(rand), weight, publishVpc)
+}
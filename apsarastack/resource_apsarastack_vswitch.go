@@ -49,6 +49,12 @@ func resourceApsaraStackSwitch() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"tags": tagsSchema(),
+			// A VSwitch always belongs to the resource group of its VPC.
+			"resource_group_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -127,11 +133,21 @@ func resourceApsaraStackSwitchRead(d *schema.ResourceData, meta interface{}) err
 	d.Set("cidr_block", vswitch.CidrBlock)
 	d.Set("name", vswitch.VSwitchName)
 	d.Set("description", vswitch.Description)
+	d.Set("resource_group_id", vswitch.ResourceGroupId)
+	tags, err := vpcService.DescribeTags(d.Id(), nil, TagResourceVSwitch)
+	if err != nil {
+		return WrapError(err)
+	}
+	d.Set("tags", vpcService.tagsToMap(tags))
 	return nil
 }
 
 func resourceApsaraStackSwitchUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.ApsaraStackClient)
+	vpcService := VpcService{client}
+	if err := vpcService.setInstanceTags(d, TagResourceVSwitch); err != nil {
+		return WrapError(err)
+	}
 	if d.IsNewResource() {
 		d.Partial(false)
 		return resourceApsaraStackSwitchRead(d, meta)
@@ -245,6 +245,16 @@ func TestAccApsarastackVSwitchBasic(t *testing.T) {
 					}),
 				),
 			},
+			{
+				Config: testAccVSwitchConfig_tags(rand),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheck(map[string]string{
+						"tags.%":       "2",
+						"tags.Created": "TF",
+						"tags.For":     "acceptance test",
+					}),
+				),
+			},
 		},
 	})
 }
@@ -379,6 +389,34 @@ resource "apsarastack_vswitch" "default" {
 `, rand)
 }
 
+func testAccVSwitchConfig_tags(rand int) string {
+	return fmt.Sprintf(
+		`
+data "apsarastack_zones" "default" {
+	available_resource_creation= "VSwitch"
+}
+variable "name" {
+  default = "tf-testAccVswitchConfig%d"
+}
+resource "apsarastack_vpc" "default" {
+  name = "${var.name}"
+  cidr_block = "172.16.0.0/12"
+}
+
+resource "apsarastack_vswitch" "default" {
+  vpc_id = "${apsarastack_vpc.default.id}"
+  cidr_block = "172.16.0.0/24"
+  availability_zone = "${data.apsarastack_zones.default.zones.0.id}"
+  name = "${var.name}_all"
+  description = "${var.name}_description_all"
+  tags = {
+    Created = "TF"
+    For     = "acceptance test"
+  }
+}
+`, rand)
+}
+
 func testAccVSwitchConfigMulti(rand int) string {
 	return fmt.Sprintf(
 		`
@@ -1,5 +1,287 @@
 package apsarastack
 
+import (
+	"strings"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/cbn"
+	"github.com/apsara-stack/terraform-provider-apsarastack/apsarastack/connectivity"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
 const ChildInstanceTypeVpc = "VPC"
 const ChildInstanceTypeVbr = "VBR"
 const ChildInstanceTypeCcn = "CCN"
+
+type CenService struct {
+	client *connectivity.ApsaraStackClient
+}
+
+func (s *CenService) DescribeCenInstance(id string) (cen cbn.Cen, err error) {
+	request := cbn.CreateDescribeCensRequest()
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = s.client.RegionId
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "cbn", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.Filter = &[]cbn.DescribeCensFilter{
+		{
+			Key:   "CenId",
+			Value: &[]string{id},
+		},
+	}
+
+	invoker := NewInvoker()
+	err = invoker.Run(func() error {
+		raw, err := s.client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+			return cbnClient.DescribeCens(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*cbn.DescribeCensResponse)
+		for _, object := range response.Cens.Cen {
+			if object.CenId == id {
+				cen = object
+				return nil
+			}
+		}
+		return WrapErrorf(Error(GetNotFoundMessage("CEN Instance", id)), NotFoundMsg, ProviderERROR, response.RequestId)
+	})
+	return
+}
+
+func (s *CenService) CenInstanceStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeCenInstance(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.Status == failState {
+				return object, object.Status, WrapError(Error(FailedToReachTargetStatus, object.Status))
+			}
+		}
+		return object, object.Status, nil
+	}
+}
+
+// DescribeCenInstanceAttachment looks up a child instance attached to a CEN. The id is formatted as <cen id>:<child instance id>.
+func (s *CenService) DescribeCenInstanceAttachment(id string) (child cbn.ChildInstance, err error) {
+	parts, err := ParseResourceId(id, 2)
+	if err != nil {
+		return child, WrapError(err)
+	}
+	cenId, childInstanceId := parts[0], parts[1]
+
+	request := cbn.CreateDescribeCenAttachedChildInstancesRequest()
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = s.client.RegionId
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "cbn", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.CenId = cenId
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+
+	for {
+		raw, err := s.client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+			return cbnClient.DescribeCenAttachedChildInstances(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"ParameterCenInstanceId"}) {
+				return child, WrapErrorf(err, NotFoundMsg, ApsaraStackSdkGoERROR)
+			}
+			return child, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*cbn.DescribeCenAttachedChildInstancesResponse)
+		for _, object := range response.ChildInstances.ChildInstance {
+			if object.ChildInstanceId == childInstanceId {
+				return object, nil
+			}
+		}
+		if len(response.ChildInstances.ChildInstance) < PageSizeLarge {
+			break
+		}
+		page, err := getNextpageNumber(request.PageNumber)
+		if err != nil {
+			return child, WrapError(err)
+		}
+		request.PageNumber = page
+	}
+	return child, WrapErrorf(Error(GetNotFoundMessage("CEN Instance Attachment", id)), NotFoundMsg, ProviderERROR)
+}
+
+func (s *CenService) CenInstanceAttachmentStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeCenInstanceAttachment(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.Status == failState {
+				return object, object.Status, WrapError(Error(FailedToReachTargetStatus, object.Status))
+			}
+		}
+		return object, object.Status, nil
+	}
+}
+
+func (s *CenService) DescribeCenBandwidthPackage(id string) (pkg cbn.CenBandwidthPackage, err error) {
+	request := cbn.CreateDescribeCenBandwidthPackagesRequest()
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = s.client.RegionId
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "cbn", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.Filter = &[]cbn.DescribeCenBandwidthPackagesFilter{
+		{
+			Key:   "CenBandwidthPackageId",
+			Value: &[]string{id},
+		},
+	}
+
+	invoker := NewInvoker()
+	err = invoker.Run(func() error {
+		raw, err := s.client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+			return cbnClient.DescribeCenBandwidthPackages(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*cbn.DescribeCenBandwidthPackagesResponse)
+		for _, object := range response.CenBandwidthPackages.CenBandwidthPackage {
+			if object.CenBandwidthPackageId == id {
+				pkg = object
+				return nil
+			}
+		}
+		return WrapErrorf(Error(GetNotFoundMessage("CEN Bandwidth Package", id)), NotFoundMsg, ProviderERROR, response.RequestId)
+	})
+	return
+}
+
+func (s *CenService) CenBandwidthPackageStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeCenBandwidthPackage(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.Status == failState {
+				return object, object.Status, WrapError(Error(FailedToReachTargetStatus, object.Status))
+			}
+		}
+		return object, object.Status, nil
+	}
+}
+
+// DescribeCenBandwidthPackageAttachment returns the bandwidth package only while it is associated with a CEN.
+func (s *CenService) DescribeCenBandwidthPackageAttachment(id string) (pkg cbn.CenBandwidthPackage, err error) {
+	pkg, err = s.DescribeCenBandwidthPackage(id)
+	if err != nil {
+		return pkg, WrapError(err)
+	}
+	if len(pkg.CenIds.CenId) < 1 {
+		return pkg, WrapErrorf(Error(GetNotFoundMessage("CEN Bandwidth Package Attachment", id)), NotFoundMsg, ProviderERROR)
+	}
+	return pkg, nil
+}
+
+// DescribeCenRouteEntry looks up a VPC route entry published to a CEN. The id is formatted as <cen id>:<route table id>:<cidr block>.
+func (s *CenService) DescribeCenRouteEntry(id string) (entry cbn.PublishedRouteEntry, err error) {
+	parts, err := ParseResourceId(id, 3)
+	if err != nil {
+		return entry, WrapError(err)
+	}
+	cenId, routeTableId, cidrBlock := parts[0], parts[1], parts[2]
+
+	vpcService := VpcService{s.client}
+	routeTable, err := vpcService.DescribeRouteTable(routeTableId)
+	if err != nil {
+		return entry, WrapError(err)
+	}
+
+	request := cbn.CreateDescribePublishedRouteEntriesRequest()
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.RegionId = s.client.RegionId
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "cbn", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.CenId = cenId
+	request.ChildInstanceId = routeTable.VpcId
+	request.ChildInstanceType = ChildInstanceTypeVpc
+	request.ChildInstanceRegionId = s.client.RegionId
+	request.ChildInstanceRouteTableId = routeTableId
+	request.DestinationCidrBlock = cidrBlock
+
+	invoker := NewInvoker()
+	err = invoker.Run(func() error {
+		raw, err := s.client.WithCenClient(func(cbnClient *cbn.Client) (interface{}, error) {
+			return cbnClient.DescribePublishedRouteEntries(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*cbn.DescribePublishedRouteEntriesResponse)
+		for _, object := range response.PublishedRouteEntries.PublishedRouteEntry {
+			if object.DestinationCidrBlock == cidrBlock && object.ChildInstanceRouteTableId == routeTableId {
+				entry = object
+				return nil
+			}
+		}
+		return WrapErrorf(Error(GetNotFoundMessage("CEN Route Entry", id)), NotFoundMsg, ProviderERROR, response.RequestId)
+	})
+	return
+}
+
+func (s *CenService) CenRouteEntryStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeCenRouteEntry(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.PublishStatus == failState {
+				return object, object.PublishStatus, WrapError(Error(FailedToReachTargetStatus, object.PublishStatus))
+			}
+		}
+		return object, object.PublishStatus, nil
+	}
+}
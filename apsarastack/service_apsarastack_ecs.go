@@ -1,6 +1,7 @@
 package apsarastack
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -508,6 +509,68 @@ func (s *EcsService) InstanceTypeValidation(targetType, zoneId string, validZone
 	return WrapError(Error("The instance type %s is solded out or is not supported in the region %s. Expected instance types: %s", targetType, s.client.RegionId, strings.Join(expectedInstanceTypes, ", ")))
 }
 
+// InstanceTypeAvailable checks the target instance type still has stock in the zone for the given charge type.
+func (s *EcsService) InstanceTypeAvailable(targetType, zoneId, chargeType string) error {
+	request := ecs.CreateDescribeAvailableResourceRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "ecs", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.DestinationResource = string(InstanceTypeResource)
+	request.IoOptimized = string(IOOptimized)
+	request.ZoneId = zoneId
+	request.InstanceChargeType = chargeType
+
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeAvailableResource(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, targetType, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	response, _ := raw.(*ecs.DescribeAvailableResourceResponse)
+
+	var validZones []ecs.AvailableZone
+	for _, zone := range response.AvailableZones.AvailableZone {
+		if zone.Status == string(SoldOut) {
+			continue
+		}
+		validZones = append(validZones, zone)
+	}
+	return s.InstanceTypeValidation(targetType, zoneId, validZones)
+}
+
+func (s *EcsService) DescribeInstanceType(id string) (instanceType ecs.InstanceType, err error) {
+	request := ecs.CreateDescribeInstanceTypesRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "ecs", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeInstanceTypes(request)
+	})
+	if err != nil {
+		return instanceType, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	response, _ := raw.(*ecs.DescribeInstanceTypesResponse)
+	for _, t := range response.InstanceTypes.InstanceType {
+		if t.InstanceTypeId == id {
+			return t, nil
+		}
+	}
+	return instanceType, WrapErrorf(Error(GetNotFoundMessage("InstanceType", id)), NotFoundMsg, ProviderERROR, response.RequestId)
+}
+
 func (s *EcsService) QueryInstancesWithKeyPair(instanceIdsStr, keyPair string) (instanceIds []string, instances []ecs.Instance, err error) {
 
 	request := ecs.CreateDescribeInstancesRequest()
@@ -540,7 +603,7 @@ func (s *EcsService) QueryInstancesWithKeyPair(instanceIdsStr, keyPair string) (
 			instanceIds = append(instanceIds, inst.InstanceId)
 			instances = append(instances, inst)
 		}
-		if len(instances) < PageSizeLarge {
+		if len(object.Instances.Instance) < PageSizeLarge {
 			break
 		}
 		if page, e := getNextpageNumber(request.PageNumber); e != nil {
@@ -655,6 +718,7 @@ func (s *EcsService) DescribeDisksByType(instanceId string, diskType DiskType) (
 		request.InstanceId = instanceId
 	}
 	request.DiskType = string(diskType)
+	request.PageSize = requests.NewInteger(PageSizeLarge)
 
 	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
 		return ecsClient.DescribeDisks(request)
@@ -978,7 +1042,11 @@ func (s *EcsService) WaitForEcsInstance(instanceId string, status Status, timeou
 		}
 		timeout = timeout - DefaultIntervalShort
 		if timeout <= 0 {
-			return GetTimeErrorFromString(GetTimeoutMessage("ECS Instance", string(status)))
+			message := GetTimeoutMessage("ECS Instance", string(status))
+			if status == Running {
+				message += s.instanceConsoleOutputMessage(instanceId)
+			}
+			return GetTimeErrorFromString(message)
 		}
 		time.Sleep(DefaultIntervalShort * time.Second)
 
@@ -986,6 +1054,88 @@ func (s *EcsService) WaitForEcsInstance(instanceId string, status Status, timeou
 	return nil
 }
 
+func (s *EcsService) DescribeInstanceConsoleOutput(id string, removeSymbols bool) (object *ecs.GetInstanceConsoleOutputResponse, err error) {
+	request := ecs.CreateGetInstanceConsoleOutputRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "ecs", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.InstanceId = id
+	request.RemoveSymbols = requests.NewBoolean(removeSymbols)
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.GetInstanceConsoleOutput(request)
+	})
+	if err != nil {
+		if IsExpectedErrors(err, []string{"InvalidInstanceId.NotFound"}) {
+			return object, WrapErrorf(err, NotFoundMsg, ApsaraStackSdkGoERROR)
+		}
+		return object, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	object, _ = raw.(*ecs.GetInstanceConsoleOutputResponse)
+	return object, nil
+}
+
+func (s *EcsService) DescribeInstanceScreenshot(id string, wakeUp bool) (object *ecs.GetInstanceScreenshotResponse, err error) {
+	request := ecs.CreateGetInstanceScreenshotRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "ecs", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.InstanceId = id
+	request.WakeUp = requests.NewBoolean(wakeUp)
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.GetInstanceScreenshot(request)
+	})
+	if err != nil {
+		if IsExpectedErrors(err, []string{"InvalidInstanceId.NotFound"}) {
+			return object, WrapErrorf(err, NotFoundMsg, ApsaraStackSdkGoERROR)
+		}
+		return object, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	object, _ = raw.(*ecs.GetInstanceScreenshotResponse)
+	return object, nil
+}
+
+// instanceConsoleOutputMessage returns the last lines of the console output of an instance, which is appended to
+// the error message when the instance fails to become Running. It is best effort and returns "" on any error.
+func (s *EcsService) instanceConsoleOutputMessage(id string) string {
+	object, err := s.DescribeInstanceConsoleOutput(id, true)
+	if err != nil || object == nil || object.ConsoleOutput == "" {
+		return ""
+	}
+	output, err := base64.StdEncoding.DecodeString(object.ConsoleOutput)
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(strings.TrimRight(string(output), "\r\n"), "\n")
+	if len(lines) > InstanceConsoleOutputErrorLines {
+		lines = lines[len(lines)-InstanceConsoleOutputErrorLines:]
+	}
+	return fmt.Sprintf("\nThe last %d lines of the console output of the instance %s:\n%s", len(lines), id, strings.Join(lines, "\n"))
+}
+
+// WithInstanceConsoleOutput appends the console output of an instance to the error of waiting for it to be Running
+// when the wait timed out.
+func (s *EcsService) WithInstanceConsoleOutput(err error, id string) error {
+	if _, ok := err.(*resource.TimeoutError); !ok {
+		return err
+	}
+	if message := s.instanceConsoleOutputMessage(id); message != "" {
+		return fmt.Errorf("%s%s", err, message)
+	}
+	return err
+}
+
 // WaitForInstance waits for instance to given status
 func (s *EcsService) InstanceStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
@@ -1666,6 +1816,40 @@ func (s *EcsService) WaitForLaunchTemplate(id string, status Status, timeout int
 	}
 }
 
+func (s *EcsService) DescribeLaunchTemplateVersionById(id string) (set ecs.LaunchTemplateVersionSet, err error) {
+	parts, err := ParseResourceId(id, 2)
+	if err != nil {
+		return set, WrapError(err)
+	}
+	version, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return set, WrapError(err)
+	}
+	return s.DescribeLaunchTemplateVersion(parts[0], version)
+}
+
+func (s *EcsService) ModifyLaunchTemplateDefaultVersion(id string, version int) error {
+	request := ecs.CreateModifyLaunchTemplateDefaultVersionRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "ecs", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.LaunchTemplateId = id
+	request.DefaultVersionNumber = requests.NewInteger(version)
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ModifyLaunchTemplateDefaultVersion(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	return nil
+}
+
 func (s *EcsService) DescribeImageShareByImageId(id string) (imageShare *ecs.DescribeImageSharePermissionResponse, err error) {
 	request := ecs.CreateDescribeImageSharePermissionRequest()
 	request.RegionId = s.client.RegionId
@@ -1712,7 +1896,7 @@ func (s *EcsService) WaitForAutoProvisioningGroup(id string, status Status, time
 				return WrapError(err)
 			}
 		}
-		if object.Status == string(status) {
+		if strings.EqualFold(object.Status, string(status)) {
 			return nil
 		}
 		time.Sleep(DefaultIntervalShort * time.Second)
@@ -1985,3 +2169,178 @@ func (s *EcsService) DescribeEcsDeploymentSet(id string) (result *EcsDescribeDep
 	}
 	return resp, nil
 }
+
+func (s *EcsService) DescribeEcsCommand(id string) (command ecs.Command, err error) {
+	request := ecs.CreateDescribeCommandsRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "ecs", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.CommandId = id
+
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeCommands(request)
+	})
+	if err != nil {
+		if IsExpectedErrors(err, []string{"InvalidCmdId.NotFound"}) {
+			return command, WrapErrorf(err, NotFoundMsg, ApsaraStackSdkGoERROR)
+		}
+		return command, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	response, _ := raw.(*ecs.DescribeCommandsResponse)
+	for _, object := range response.Commands.Command {
+		if object.CommandId == id {
+			return object, nil
+		}
+	}
+	return command, WrapErrorf(Error(GetNotFoundMessage("EcsCommand", id)), NotFoundMsg, ProviderERROR, response.RequestId)
+}
+
+func (s *EcsService) DescribeEcsInvocation(id string) (invocation ecs.Invocation, err error) {
+	request := ecs.CreateDescribeInvocationsRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "ecs", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.InvokeId = id
+
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeInvocations(request)
+	})
+	if err != nil {
+		return invocation, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	response, _ := raw.(*ecs.DescribeInvocationsResponse)
+	for _, object := range response.Invocations.Invocation {
+		if object.InvokeId == id {
+			return object, nil
+		}
+	}
+	return invocation, WrapErrorf(Error(GetNotFoundMessage("EcsInvocation", id)), NotFoundMsg, ProviderERROR, response.RequestId)
+}
+
+// DescribeEcsInvocationResults returns the result of an invocation on every instance it runs on.
+func (s *EcsService) DescribeEcsInvocationResults(id string) (results []ecs.InvocationResult, err error) {
+	request := ecs.CreateDescribeInvocationResultsRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "ecs", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.InvokeId = id
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+
+	for {
+		raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DescribeInvocationResults(request)
+		})
+		if err != nil {
+			return results, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*ecs.DescribeInvocationResultsResponse)
+		results = append(results, response.Invocation.InvocationResults.InvocationResult...)
+		if len(response.Invocation.InvocationResults.InvocationResult) < PageSizeLarge {
+			break
+		}
+		if page, err := getNextpageNumber(request.PageNumber); err != nil {
+			return results, WrapError(err)
+		} else {
+			request.PageNumber = page
+		}
+	}
+	return results, nil
+}
+
+func (s *EcsService) EcsInvocationStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeEcsInvocation(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.InvocationStatus == failState {
+				return object, object.InvocationStatus, WrapError(Error(FailedToReachTargetStatus, object.InvocationStatus))
+			}
+		}
+		return object, object.InvocationStatus, nil
+	}
+}
+
+func (s *EcsService) DescribeEcsSnapshotGroup(id string) (ecs.SnapshotGroup, error) {
+	var group ecs.SnapshotGroup
+	request := ecs.CreateDescribeSnapshotGroupsRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "ecs", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.SnapshotGroupId = &[]string{id}
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeSnapshotGroups(request)
+	})
+	if err != nil {
+		return group, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	response, _ := raw.(*ecs.DescribeSnapshotGroupsResponse)
+	for _, item := range response.SnapshotGroups.SnapshotGroup {
+		if item.SnapshotGroupId == id {
+			return item, nil
+		}
+	}
+	return group, WrapErrorf(Error(GetNotFoundMessage("EcsSnapshotGroup", id)), NotFoundMsg, ProviderERROR, response.RequestId)
+}
+
+func (s *EcsService) EcsSnapshotGroupStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeEcsSnapshotGroup(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.Status == failState {
+				return object, object.Status, WrapError(Error(FailedToReachTargetStatus, object.Status))
+			}
+		}
+		return object, object.Status, nil
+	}
+}
+
+// setDefaultSystemDiskEncryption encrypts the system disk with the KMS key of the provider default_disk_encryption.
+func setDefaultSystemDiskEncryption(client *connectivity.ApsaraStackClient, queryParams map[string]string) {
+	if client.Config.DefaultDiskKMSKeyId == "" {
+		return
+	}
+	setExtraQueryParams(queryParams, map[string]string{
+		"SystemDisk.Encrypted": "true",
+		"SystemDisk.KMSKeyId":  client.Config.DefaultDiskKMSKeyId,
+	})
+}
@@ -389,7 +389,17 @@ func (s *VpcService) DescribeRouteEntry(id string) (*vpc.RouteEntry, error) {
 		}
 		for _, table := range response.RouteTables.RouteTable {
 			for _, entry := range table.RouteEntrys.RouteEntry {
-				if entry.DestinationCidrBlock == cidr && entry.NextHopType == nexthop_type && entry.InstanceId == nexthop_id {
+				if entry.DestinationCidrBlock != cidr {
+					continue
+				}
+				// An ECMP entry has no single next hop, so it is identified by its destination and next hop list.
+				if nexthop_type == string(NextHopEcmp) {
+					if len(entry.NextHops.NextHop) > 1 {
+						return &entry, nil
+					}
+					continue
+				}
+				if entry.NextHopType == nexthop_type && entry.InstanceId == nexthop_id {
 					return &entry, nil
 				}
 			}
@@ -517,7 +527,7 @@ func (s *VpcService) WaitForCenInstanceGrant(id string, status Status, timeout i
 	if err != nil {
 		return WrapError(err)
 	}
-	instanceId := parts[1]
+	cenId := parts[0]
 	ownerId := parts[2]
 	for {
 		object, err := s.DescribeCenInstanceGrant(id)
@@ -530,11 +540,11 @@ func (s *VpcService) WaitForCenInstanceGrant(id string, status Status, timeout i
 				return WrapError(err)
 			}
 		}
-		if object.CenInstanceId == instanceId && fmt.Sprint(object.CenOwnerId) == ownerId && status != Deleted {
+		if object.CenInstanceId == cenId && fmt.Sprint(object.CenOwnerId) == ownerId && status != Deleted {
 			break
 		}
 		if time.Now().After(deadline) {
-			return WrapErrorf(err, WaitTimeoutMsg, id, GetFunc(1), timeout, object.CenInstanceId, instanceId, ProviderERROR)
+			return WrapErrorf(err, WaitTimeoutMsg, id, GetFunc(1), timeout, object.CenInstanceId, cenId, ProviderERROR)
 		}
 		time.Sleep(DefaultIntervalShort * time.Second)
 	}
@@ -1458,3 +1468,525 @@ func (s *VpcService) DeleteAclResources(id string) (object map[string]interface{
 	}
 	return object, nil
 }
+
+func (s *VpcService) DescribeVpnGateway(id string) (v vpc.DescribeVpnGatewayResponse, err error) {
+	request := vpc.CreateDescribeVpnGatewayRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "vpc", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.VpnGatewayId = id
+
+	invoker := NewInvoker()
+	err = invoker.Run(func() error {
+		raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DescribeVpnGateway(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"Forbidden", "InvalidVpnGatewayInstanceId.NotFound"}) {
+				return WrapErrorf(err, NotFoundMsg, ApsaraStackSdkGoERROR)
+			}
+			return WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*vpc.DescribeVpnGatewayResponse)
+		if response.VpnGatewayId != id {
+			return WrapErrorf(Error(GetNotFoundMessage("VpnGateway", id)), NotFoundMsg, ProviderERROR, response.RequestId)
+		}
+		v = *response
+		return nil
+	})
+	return
+}
+
+func (s *VpcService) VpnGatewayStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeVpnGateway(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.Status == failState {
+				return object, object.Status, WrapError(Error(FailedToReachTargetStatus, object.Status))
+			}
+		}
+
+		return object, object.Status, nil
+	}
+}
+
+func (s *VpcService) DescribeVpnCustomerGateway(id string) (v vpc.DescribeCustomerGatewayResponse, err error) {
+	request := vpc.CreateDescribeCustomerGatewayRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "vpc", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.CustomerGatewayId = id
+
+	invoker := NewInvoker()
+	err = invoker.Run(func() error {
+		raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DescribeCustomerGateway(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"Forbidden", "InvalidCustomerGatewayInstanceId.NotFound"}) {
+				return WrapErrorf(err, NotFoundMsg, ApsaraStackSdkGoERROR)
+			}
+			return WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*vpc.DescribeCustomerGatewayResponse)
+		if response.CustomerGatewayId != id {
+			return WrapErrorf(Error(GetNotFoundMessage("VpnCustomerGateway", id)), NotFoundMsg, ProviderERROR, response.RequestId)
+		}
+		v = *response
+		return nil
+	})
+	return
+}
+
+func (s *VpcService) DescribeVpnConnection(id string) (v vpc.DescribeVpnConnectionResponse, err error) {
+	request := vpc.CreateDescribeVpnConnectionRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "vpc", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.VpnConnectionId = id
+
+	invoker := NewInvoker()
+	err = invoker.Run(func() error {
+		raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DescribeVpnConnection(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"Forbidden", "InvalidVpnConnectionInstanceId.NotFound"}) {
+				return WrapErrorf(err, NotFoundMsg, ApsaraStackSdkGoERROR)
+			}
+			return WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*vpc.DescribeVpnConnectionResponse)
+		if response.VpnConnectionId != id {
+			return WrapErrorf(Error(GetNotFoundMessage("VpnConnection", id)), NotFoundMsg, ProviderERROR, response.RequestId)
+		}
+		v = *response
+		return nil
+	})
+	return
+}
+
+// DescribeVpnRouteEntry looks up a destination based route of a VPN gateway. The id is formatted as <vpn gateway id>:<next hop>:<route dest>.
+func (s *VpcService) DescribeVpnRouteEntry(id string) (v vpc.VpnRouteEntry, err error) {
+	parts, err := ParseResourceId(id, 3)
+	if err != nil {
+		return v, WrapError(err)
+	}
+	vpnGatewayId, nextHop, routeDest := parts[0], parts[1], parts[2]
+
+	request := vpc.CreateDescribeVpnRouteEntriesRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "vpc", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.VpnGatewayId = vpnGatewayId
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+
+	for {
+		raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DescribeVpnRouteEntries(request)
+		})
+		if err != nil {
+			if IsExpectedErrors(err, []string{"Forbidden", "InvalidVpnGatewayInstanceId.NotFound"}) {
+				return v, WrapErrorf(err, NotFoundMsg, ApsaraStackSdkGoERROR)
+			}
+			return v, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*vpc.DescribeVpnRouteEntriesResponse)
+		for _, entry := range response.VpnRouteEntries.VpnRouteEntry {
+			if entry.NextHop == nextHop && entry.RouteDest == routeDest {
+				return entry, nil
+			}
+		}
+		if len(response.VpnRouteEntries.VpnRouteEntry) < PageSizeLarge {
+			return v, WrapErrorf(Error(GetNotFoundMessage("VpnRouteEntry", id)), NotFoundMsg, ProviderERROR, response.RequestId)
+		}
+		page, err := getNextpageNumber(request.PageNumber)
+		if err != nil {
+			return v, WrapError(err)
+		}
+		request.PageNumber = page
+	}
+}
+
+func (s *VpcService) VpnRouteEntryStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeVpnRouteEntry(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.State == failState {
+				return object, object.State, WrapError(Error(FailedToReachTargetStatus, object.State))
+			}
+		}
+
+		return object, object.State, nil
+	}
+}
+
+func (s *VpcService) DescribeVpcFlowLog(id string) (v vpc.FlowLog, err error) {
+	request := vpc.CreateDescribeFlowLogsRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "vpc", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.FlowLogId = id
+
+	invoker := NewInvoker()
+	err = invoker.Run(func() error {
+		raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DescribeFlowLogs(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*vpc.DescribeFlowLogsResponse)
+		for _, object := range response.FlowLogs.FlowLog {
+			if object.FlowLogId == id {
+				v = object
+				return nil
+			}
+		}
+		return WrapErrorf(Error(GetNotFoundMessage("VpcFlowLog", id)), NotFoundMsg, ProviderERROR, response.RequestId)
+	})
+	return
+}
+
+func (s *VpcService) VpcFlowLogStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeVpcFlowLog(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.Status == failState {
+				return object, object.Status, WrapError(Error(FailedToReachTargetStatus, object.Status))
+			}
+		}
+
+		return object, object.Status, nil
+	}
+}
+
+func (s *VpcService) DescribeHaVip(id string) (v vpc.HaVip, err error) {
+	request := vpc.CreateDescribeHaVipsRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "vpc", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.Filter = &[]vpc.DescribeHaVipsFilter{
+		{
+			Key:   "HaVipId",
+			Value: &[]string{id},
+		},
+	}
+
+	invoker := NewInvoker()
+	err = invoker.Run(func() error {
+		raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DescribeHaVips(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*vpc.DescribeHaVipsResponse)
+		for _, object := range response.HaVips.HaVip {
+			if object.HaVipId == id {
+				v = object
+				return nil
+			}
+		}
+		return WrapErrorf(Error(GetNotFoundMessage("HaVip", id)), NotFoundMsg, ProviderERROR, response.RequestId)
+	})
+	return
+}
+
+func (s *VpcService) HaVipStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeHaVip(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.Status == failState {
+				return object, object.Status, WrapError(Error(FailedToReachTargetStatus, object.Status))
+			}
+		}
+
+		return object, object.Status, nil
+	}
+}
+
+// DescribeHaVipAttachment looks up an instance bound to a HaVip. The id is formatted as <havip id>:<instance id>.
+func (s *VpcService) DescribeHaVipAttachment(id string) (v vpc.HaVip, err error) {
+	parts, err := ParseResourceId(id, 2)
+	if err != nil {
+		return v, WrapError(err)
+	}
+	haVipId, instanceId := parts[0], parts[1]
+
+	object, err := s.DescribeHaVip(haVipId)
+	if err != nil {
+		return v, WrapError(err)
+	}
+	for _, associated := range object.AssociatedInstances.AssociatedInstance {
+		if associated == instanceId {
+			return object, nil
+		}
+	}
+	return v, WrapErrorf(Error(GetNotFoundMessage("HaVipAttachment", id)), NotFoundMsg, ProviderERROR)
+}
+
+func (s *VpcService) HaVipAttachmentStateRefreshFunc(id string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		object, err := s.DescribeHaVipAttachment(id)
+		if err != nil {
+			if NotFoundError(err) {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			return nil, "", WrapError(err)
+		}
+
+		for _, failState := range failStates {
+			if object.Status == failState {
+				return object, object.Status, WrapError(Error(FailedToReachTargetStatus, object.Status))
+			}
+		}
+
+		return object, object.Status, nil
+	}
+}
+
+// setResourceGroup moves a VPC, EIP or bandwidth package to the resource group in resource_group_id.
+// resourceType is the MoveResourceGroup type, e.g. vpc, eip or bandwidthpackage.
+func (s *VpcService) setResourceGroup(d *schema.ResourceData, resourceType string) error {
+	if !d.HasChange("resource_group_id") {
+		return nil
+	}
+	v, ok := d.GetOk("resource_group_id")
+	if !ok {
+		return nil
+	}
+
+	request := vpc.CreateMoveResourceGroupRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "vpc", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.ResourceId = d.Id()
+	request.ResourceType = resourceType
+	request.NewResourceGroupId = v.(string)
+
+	raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.MoveResourceGroup(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), ApsaraStackSdkGoERROR)
+	}
+	addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+	d.SetPartial("resource_group_id")
+	return nil
+}
+
+// DescribeNatForwardTable lists every DNAT entry in a NAT gateway forward table.
+func (s *VpcService) DescribeNatForwardTable(id string) (entries []vpc.ForwardTableEntry, err error) {
+	request := vpc.CreateDescribeForwardTableEntriesRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "vpc", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.ForwardTableId = id
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+
+	invoker := NewInvoker()
+	for {
+		var raw interface{}
+		if err := invoker.Run(func() error {
+			response, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+				return vpcClient.DescribeForwardTableEntries(request)
+			})
+			raw = response
+			return err
+		}); err != nil {
+			if IsExpectedErrors(err, []string{"InvalidForwardTableId.NotFound"}) {
+				return entries, WrapErrorf(Error(GetNotFoundMessage("NatForwardTable", id)), NotFoundMsg, ProviderERROR)
+			}
+			return entries, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*vpc.DescribeForwardTableEntriesResponse)
+		entries = append(entries, response.ForwardTableEntries.ForwardTableEntry...)
+		if len(response.ForwardTableEntries.ForwardTableEntry) < PageSizeLarge {
+			break
+		}
+		page, err := getNextpageNumber(request.PageNumber)
+		if err != nil {
+			return entries, WrapError(err)
+		}
+		request.PageNumber = page
+	}
+	return entries, nil
+}
+
+// NatForwardTableEntriesStateRefreshFunc reports Available once all entryIds are available and
+// Deleted once none of them is left, so a batch of changes is waited for with one listing per poll.
+func (s *VpcService) NatForwardTableEntriesStateRefreshFunc(id string, entryIds []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		entries, err := s.DescribeNatForwardTable(id)
+		if err != nil {
+			return nil, "", WrapError(err)
+		}
+		statuses := make(map[string]string, len(entries))
+		for _, entry := range entries {
+			statuses[entry.ForwardEntryId] = entry.Status
+		}
+		return entries, natTableEntriesStatus(statuses, entryIds), nil
+	}
+}
+
+// DescribeNatSnatTable lists every SNAT entry in a NAT gateway SNAT table.
+func (s *VpcService) DescribeNatSnatTable(id string) (entries []vpc.SnatTableEntry, err error) {
+	request := vpc.CreateDescribeSnatTableEntriesRequest()
+	request.RegionId = s.client.RegionId
+	if strings.ToLower(s.client.Config.Protocol) == "https" {
+		request.Scheme = "https"
+	} else {
+		request.Scheme = "http"
+	}
+	request.Headers = map[string]string{"RegionId": s.client.RegionId}
+	request.QueryParams = map[string]string{"AccessKeySecret": s.client.SecretKey, "Product": "vpc", "Department": s.client.Department, "ResourceGroup": s.client.ResourceGroup}
+	request.SnatTableId = id
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
+
+	invoker := NewInvoker()
+	for {
+		var raw interface{}
+		if err := invoker.Run(func() error {
+			response, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+				return vpcClient.DescribeSnatTableEntries(request)
+			})
+			raw = response
+			return err
+		}); err != nil {
+			if IsExpectedErrors(err, []string{"InvalidSnatTableId.NotFound"}) {
+				return entries, WrapErrorf(Error(GetNotFoundMessage("NatSnatTable", id)), NotFoundMsg, ProviderERROR)
+			}
+			return entries, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), ApsaraStackSdkGoERROR)
+		}
+		addDebug(request.GetActionName(), raw, request.RpcRequest, request)
+		response, _ := raw.(*vpc.DescribeSnatTableEntriesResponse)
+		entries = append(entries, response.SnatTableEntries.SnatTableEntry...)
+		if len(response.SnatTableEntries.SnatTableEntry) < PageSizeLarge {
+			break
+		}
+		page, err := getNextpageNumber(request.PageNumber)
+		if err != nil {
+			return entries, WrapError(err)
+		}
+		request.PageNumber = page
+	}
+	return entries, nil
+}
+
+// NatSnatTableEntriesStateRefreshFunc is the SNAT counterpart of NatForwardTableEntriesStateRefreshFunc.
+func (s *VpcService) NatSnatTableEntriesStateRefreshFunc(id string, entryIds []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		entries, err := s.DescribeNatSnatTable(id)
+		if err != nil {
+			return nil, "", WrapError(err)
+		}
+		statuses := make(map[string]string, len(entries))
+		for _, entry := range entries {
+			statuses[entry.SnatEntryId] = entry.Status
+		}
+		return entries, natTableEntriesStatus(statuses, entryIds), nil
+	}
+}
+
+func natTableEntriesStatus(statuses map[string]string, entryIds []string) string {
+	found, available := 0, 0
+	for _, entryId := range entryIds {
+		if status, ok := statuses[entryId]; ok {
+			found++
+			if status == string(Available) {
+				available++
+			}
+		}
+	}
+	switch {
+	case found == 0:
+		return string(Deleted)
+	case available == len(entryIds):
+		return string(Available)
+	}
+	return "Pending"
+}